@@ -0,0 +1,274 @@
+package goassist
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+// ErrInvalidBitSetEncoding is returned by BitSet.UnmarshalBinary for malformed input.
+var ErrInvalidBitSetEncoding = errors.New("goassist: invalid BitSet encoding")
+
+// MaxBitSetJSONID is the largest member BitSet.UnmarshalJSON accepts. A BitSet needs memory
+// proportional to its largest member, so the limit keeps untrusted input such as [9000000000000000000] from
+// causing huge allocations; this one allows sets of up to 2 MiB.
+const MaxBitSetJSONID = 1<<24 - 1
+
+// BitSet is a dense set of non-negative integers backed by 64-bit words.
+// It is suited to small integer IDs such as feature flags and permission masks,
+// where membership tests on a slice with Contains would be linear.
+//
+// The zero value is an empty set ready to use.
+//
+// Example:
+//
+//	var perms BitSet
+//	perms.Set(1)
+//	perms.Set(4)
+//	canWrite := perms.Test(4)
+//	// canWrite is true
+//	ids := perms.ToSlice()
+//	// ids is []int{1, 4}
+type BitSet struct {
+	words []uint64
+}
+
+// NewBitSet creates an empty BitSet with room for integers below n without growing.
+func NewBitSet(n int) *BitSet {
+	return &BitSet{words: make([]uint64, 0, (max(n, 0)+63)/64)}
+}
+
+// BitSetFromSlice creates a BitSet containing every integer in the slice.
+// It panics if the slice contains a negative number. Memory grows with the largest
+// number, so validate untrusted input first.
+//
+// Example:
+//
+//	flags := BitSetFromSlice([]int{3, 1, 3, 7})
+//	// flags.ToSlice() is []int{1, 3, 7}
+func BitSetFromSlice(ids []int) *BitSet {
+	b := &BitSet{}
+	for _, id := range ids {
+		b.Set(id)
+	}
+	return b
+}
+
+// Set adds i to the set. It panics if i is negative.
+func (b *BitSet) Set(i int) {
+	if i < 0 {
+		panic("goassist.BitSet: negative index")
+	}
+	w := i / 64
+	if w >= len(b.words) {
+		b.words = append(b.words, make([]uint64, w-len(b.words)+1)...)
+	}
+	b.words[w] |= 1 << (i % 64)
+}
+
+// Clear removes i from the set.
+func (b *BitSet) Clear(i int) {
+	if i < 0 || i/64 >= len(b.words) {
+		return
+	}
+	b.words[i/64] &^= 1 << (i % 64)
+}
+
+// Test reports whether i is in the set.
+func (b *BitSet) Test(i int) bool {
+	if i < 0 || i/64 >= len(b.words) {
+		return false
+	}
+	return b.words[i/64]&(1<<(i%64)) != 0
+}
+
+// Count returns the number of integers in the set.
+func (b *BitSet) Count() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Equal reports whether both sets contain the same integers.
+func (b *BitSet) Equal(other *BitSet) bool {
+	return slices.Equal(b.trimmed(), other.trimmed())
+}
+
+// Clone returns a copy of the set.
+func (b *BitSet) Clone() *BitSet {
+	return &BitSet{words: slices.Clone(b.trimmed())}
+}
+
+// Union returns a new set holding the integers present in either set.
+//
+// Example:
+//
+//	a := BitSetFromSlice([]int{1, 2})
+//	b := BitSetFromSlice([]int{2, 3})
+//	// a.Union(b).ToSlice() is []int{1, 2, 3}
+func (b *BitSet) Union(other *BitSet) *BitSet {
+	result := b.Clone()
+	result.UnionWith(other)
+	return result
+}
+
+// Intersect returns a new set holding the integers present in both sets.
+//
+// Example:
+//
+//	a := BitSetFromSlice([]int{1, 2})
+//	b := BitSetFromSlice([]int{2, 3})
+//	// a.Intersect(b).ToSlice() is []int{2}
+func (b *BitSet) Intersect(other *BitSet) *BitSet {
+	result := b.Clone()
+	result.IntersectWith(other)
+	return result
+}
+
+// Difference returns a new set holding the integers present in b but not in other.
+//
+// Example:
+//
+//	a := BitSetFromSlice([]int{1, 2})
+//	b := BitSetFromSlice([]int{2, 3})
+//	// a.Difference(b).ToSlice() is []int{1}
+func (b *BitSet) Difference(other *BitSet) *BitSet {
+	result := b.Clone()
+	result.DifferenceWith(other)
+	return result
+}
+
+// UnionWith adds every integer of other to b in place.
+func (b *BitSet) UnionWith(other *BitSet) {
+	if len(other.words) > len(b.words) {
+		b.words = append(b.words, make([]uint64, len(other.words)-len(b.words))...)
+	}
+	for i, w := range other.words {
+		b.words[i] |= w
+	}
+}
+
+// IntersectWith removes from b every integer not present in other, in place.
+func (b *BitSet) IntersectWith(other *BitSet) {
+	for i := range b.words {
+		if i < len(other.words) {
+			b.words[i] &= other.words[i]
+		} else {
+			b.words[i] = 0
+		}
+	}
+}
+
+// DifferenceWith removes from b every integer present in other, in place.
+func (b *BitSet) DifferenceWith(other *BitSet) {
+	for i := range min(len(b.words), len(other.words)) {
+		b.words[i] &^= other.words[i]
+	}
+}
+
+// All returns an iterator over the integers in the set in ascending order.
+//
+// Example:
+//
+//	flags := BitSetFromSlice([]int{7, 1, 3})
+//	for id := range flags.All() {
+//		fmt.Println(id)
+//	}
+//	// 1
+//	// 3
+//	// 7
+func (b *BitSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, w := range b.words {
+			for w != 0 {
+				bit := bits.TrailingZeros64(w)
+				if !yield(i*64 + bit) {
+					return
+				}
+				w &= w - 1
+			}
+		}
+	}
+}
+
+// ToSlice returns the integers in the set as a sorted slice, ready to be used with BinarySearch.
+func (b *BitSet) ToSlice() []int {
+	result := make([]int, 0, b.Count())
+	for i := range b.All() {
+		result = append(result, i)
+	}
+	return result
+}
+
+// MarshalBinary encodes the set as a uvarint word count followed by little-endian 64-bit words.
+// Trailing empty words are omitted, so equal sets always have equal encodings.
+func (b *BitSet) MarshalBinary() ([]byte, error) {
+	words := b.trimmed()
+	data := binary.AppendUvarint(make([]byte, 0, binary.MaxVarintLen64+8*len(words)), uint64(len(words)))
+	for _, w := range words {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a set produced by MarshalBinary, replacing the contents of b.
+func (b *BitSet) UnmarshalBinary(data []byte) error {
+	n, size := binary.Uvarint(data)
+	if size <= 0 {
+		return ErrInvalidBitSetEncoding
+	}
+	data = data[size:]
+	// Compare by dividing, not by computing 8*n, which can overflow for huge n.
+	if uint64(len(data))%8 != 0 || uint64(len(data))/8 != n {
+		return ErrInvalidBitSetEncoding
+	}
+
+	words := make([]uint64, n)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+	b.words = words
+
+	return nil
+}
+
+// MarshalJSON encodes the set as a JSON array of its members in ascending order.
+//
+// Example:
+//
+//	data, err := json.Marshal(BitSetFromSlice([]int{4, 1}))
+//	// data is [1,4]
+func (b *BitSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.ToSlice())
+}
+
+// UnmarshalJSON decodes a JSON array of integers between 0 and MaxBitSetJSONID, replacing
+// the contents of b. Other members make it return an error wrapping ErrInvalidBitSetEncoding.
+func (b *BitSet) UnmarshalJSON(data []byte) error {
+	var ids []int
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return err
+	}
+	if i := slices.IndexFunc(ids, func(id int) bool { return id < 0 || id > MaxBitSetJSONID }); i >= 0 {
+		return fmt.Errorf("%w: member %d out of range [0, %d]", ErrInvalidBitSetEncoding, ids[i], MaxBitSetJSONID)
+	}
+	b.words = nil
+	for _, id := range ids {
+		b.Set(id)
+	}
+	return nil
+}
+
+func (b *BitSet) trimmed() []uint64 {
+	n := len(b.words)
+	for n > 0 && b.words[n-1] == 0 {
+		n--
+	}
+	return b.words[:n]
+}
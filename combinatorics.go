@@ -0,0 +1,269 @@
+package goassist
+
+import "iter"
+
+// Permutations returns an iterator over all orderings of the elements of the slice.
+// Elements are told apart by position, so duplicates produce repeated permutations.
+// Permutations are generated in lexicographic order of positions: if the elements of s are
+// distinct and sorted in ascending order, every permutation compares greater than the previous
+// one with Compare.
+// Each permutation is a new slice; use PermutationsBuf to avoid the allocations.
+//
+// Example:
+//
+//	for p := range Permutations([]int{1, 2, 3}) {
+//		fmt.Println(p)
+//	}
+//	// [1 2 3] [1 3 2] [2 1 3] [2 3 1] [3 1 2] [3 2 1]
+func Permutations[S ~[]E, E any](s S) iter.Seq[S] {
+	return pick(s, permutationIndices(len(s)), false)
+}
+
+// PermutationsBuf is like Permutations but yields the same buffer on every iteration.
+// The yielded slice is only valid until the next iteration; Clone it to keep it.
+func PermutationsBuf[S ~[]E, E any](s S) iter.Seq[S] {
+	return pick(s, permutationIndices(len(s)), true)
+}
+
+// Combinations returns an iterator over all ways to choose k elements of the slice,
+// keeping their original order. Combinations are generated in lexicographic order of positions:
+// if the elements of s are distinct and sorted in ascending order, every combination compares
+// greater than the previous one with Compare.
+// Each combination is a new slice; use CombinationsBuf to avoid the allocations.
+//
+// Example:
+//
+//	for c := range Combinations([]string{"a", "b", "c", "d"}, 2) {
+//		fmt.Println(c)
+//	}
+//	// [a b] [a c] [a d] [b c] [b d] [c d]
+func Combinations[S ~[]E, E any](s S, k int) iter.Seq[S] {
+	return pick(s, combinationIndices(len(s), k, false), false)
+}
+
+// CombinationsBuf is like Combinations but yields the same buffer on every iteration.
+// The yielded slice is only valid until the next iteration; Clone it to keep it.
+func CombinationsBuf[S ~[]E, E any](s S, k int) iter.Seq[S] {
+	return pick(s, combinationIndices(len(s), k, false), true)
+}
+
+// CombinationsWithReplacement returns an iterator over all ways to choose k elements of the slice
+// when every element may be chosen several times, in the same order as Combinations.
+//
+// Example:
+//
+//	for c := range CombinationsWithReplacement([]string{"a", "b", "c"}, 2) {
+//		fmt.Println(c)
+//	}
+//	// [a a] [a b] [a c] [b b] [b c] [c c]
+func CombinationsWithReplacement[S ~[]E, E any](s S, k int) iter.Seq[S] {
+	return pick(s, combinationIndices(len(s), k, true), false)
+}
+
+// CombinationsWithReplacementBuf is like CombinationsWithReplacement but yields the same buffer
+// on every iteration. The yielded slice is only valid until the next iteration; Clone it to keep it.
+func CombinationsWithReplacementBuf[S ~[]E, E any](s S, k int) iter.Seq[S] {
+	return pick(s, combinationIndices(len(s), k, true), true)
+}
+
+// PowerSet returns an iterator over all subsets of the slice, keeping the original order
+// within each subset. Subsets are generated in lexicographic order of positions, starting with
+// the empty subset: if the elements of s are distinct and sorted in ascending order, every subset
+// compares greater than the previous one with Compare. Each subset is a new slice; use PowerSetBuf to avoid the allocations.
+//
+// Example:
+//
+//	for sub := range PowerSet([]int{1, 2, 3}) {
+//		fmt.Println(sub)
+//	}
+//	// [] [1] [1 2] [1 2 3] [1 3] [2] [2 3] [3]
+func PowerSet[S ~[]E, E any](s S) iter.Seq[S] {
+	return pick(s, powerSetIndices(len(s)), false)
+}
+
+// PowerSetBuf is like PowerSet but yields the same buffer on every iteration.
+// The yielded slice is only valid until the next iteration; Clone it to keep it.
+func PowerSetBuf[S ~[]E, E any](s S) iter.Seq[S] {
+	return pick(s, powerSetIndices(len(s)), true)
+}
+
+// CartesianProduct returns an iterator over every way to pick one element from each slice,
+// in lexicographic order of positions with the last slice varying fastest: if every slice is
+// sorted in ascending order without duplicates, every tuple compares greater than the previous
+// one with Compare.
+// Each tuple is a new slice; use CartesianProductBuf to avoid the allocations.
+//
+// Example:
+//
+//	oses := []string{"linux", "darwin"}
+//	arches := []string{"amd64", "arm64"}
+//	for target := range CartesianProduct(oses, arches) {
+//		fmt.Println(target)
+//	}
+//	// [linux amd64] [linux arm64] [darwin amd64] [darwin arm64]
+func CartesianProduct[S ~[]E, E any](sets ...S) iter.Seq[S] {
+	return cartesianProduct(sets, false)
+}
+
+// CartesianProductBuf is like CartesianProduct but yields the same buffer on every iteration.
+// The yielded slice is only valid until the next iteration; Clone it to keep it.
+func CartesianProductBuf[S ~[]E, E any](sets ...S) iter.Seq[S] {
+	return cartesianProduct(sets, true)
+}
+
+func cartesianProduct[S ~[]E, E any](sets []S, reuse bool) iter.Seq[S] {
+	return func(yield func(S) bool) {
+		for _, set := range sets {
+			if len(set) == 0 {
+				return
+			}
+		}
+
+		idx := make([]int, len(sets))
+		var buf S
+		for {
+			if reuse {
+				buf = buf[:0]
+			} else {
+				buf = make(S, 0, len(sets))
+			}
+			for j, i := range idx {
+				buf = append(buf, sets[j][i])
+			}
+			if !yield(buf) {
+				return
+			}
+
+			j := len(idx) - 1
+			for ; j >= 0; j-- {
+				if idx[j]++; idx[j] < len(sets[j]) {
+					break
+				}
+				idx[j] = 0
+			}
+			if j < 0 {
+				return
+			}
+		}
+	}
+}
+
+// pick maps every index tuple to the corresponding elements of s.
+func pick[S ~[]E, E any](s S, indices iter.Seq[[]int], reuse bool) iter.Seq[S] {
+	return func(yield func(S) bool) {
+		var buf S
+		for idx := range indices {
+			if reuse {
+				buf = buf[:0]
+			} else {
+				buf = make(S, 0, len(idx))
+			}
+			for _, i := range idx {
+				buf = append(buf, s[i])
+			}
+			if !yield(buf) {
+				return
+			}
+		}
+	}
+}
+
+func permutationIndices(n int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		idx := make([]int, n)
+		for i := range idx {
+			idx[i] = i
+		}
+		for {
+			if !yield(idx) {
+				return
+			}
+
+			// Advance to the next permutation in lexicographic order.
+			i := n - 2
+			for i >= 0 && idx[i] >= idx[i+1] {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			j := n - 1
+			for idx[j] <= idx[i] {
+				j--
+			}
+			idx[i], idx[j] = idx[j], idx[i]
+			Reverse(idx[i+1:])
+		}
+	}
+}
+
+func combinationIndices(n, k int, replacement bool) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		if k < 0 || (!replacement && k > n) || (replacement && n == 0 && k > 0) {
+			return
+		}
+
+		idx := make([]int, k)
+		if !replacement {
+			for i := range idx {
+				idx[i] = i
+			}
+		}
+		for {
+			if !yield(idx) {
+				return
+			}
+
+			// Find the rightmost position that can still move forward.
+			i := k - 1
+			for i >= 0 && idx[i] == combinationLimit(n, k, i, replacement) {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			idx[i]++
+			for j := i + 1; j < k; j++ {
+				if replacement {
+					idx[j] = idx[i]
+				} else {
+					idx[j] = idx[j-1] + 1
+				}
+			}
+		}
+	}
+}
+
+func combinationLimit(n, k, i int, replacement bool) int {
+	if replacement {
+		return n - 1
+	}
+	return n - k + i
+}
+
+func powerSetIndices(n int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		idx := make([]int, 0, n)
+		for {
+			if !yield(idx) {
+				return
+			}
+
+			switch last := len(idx) - 1; {
+			case last < 0:
+				if n == 0 {
+					return
+				}
+				idx = append(idx, 0)
+			case idx[last] < n-1:
+				idx = append(idx, idx[last]+1)
+			default:
+				idx = idx[:last]
+				if len(idx) == 0 {
+					return
+				}
+				idx[len(idx)-1]++
+			}
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package goassist
+
+// Signed is a constraint satisfied by all signed integer types.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is a constraint satisfied by all unsigned integer types.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer is a constraint satisfied by all integer types.
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float is a constraint satisfied by all floating-point types.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is a constraint satisfied by all integer and floating-point types.
+type Number interface {
+	Integer | Float
+}
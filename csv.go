@@ -0,0 +1,312 @@
+package goassist
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// ErrCSVType is returned by ToCSV and FromCSV when the element type is not a struct
+// or has a field of a type that cannot be written as a CSV cell.
+var ErrCSVType = errors.New("goassist: CSV")
+
+// ToCSV writes the slice of structs as CSV: a header row with the column names followed by one row
+// per element. Columns come from exported fields in declaration order, including fields promoted
+// from embedded structs and struct pointers. The `csv` struct tag renames a column, and `csv:"-"`
+// leaves the field out. Two fields with the same column name are an error.
+//
+// Supported field types are strings, booleans, integers, floats, types implementing
+// encoding.TextMarshaler and encoding.TextUnmarshaler (such as time.Time), and pointers to them;
+// nil pointers, including nil embedded struct pointers, are written as empty cells.
+//
+// Example:
+//
+//	type User struct {
+//		ID       int    `csv:"id"`
+//		Name     string `csv:"name"`
+//		Password string `csv:"-"`
+//	}
+//	active := Filter(users, func(u User) bool { return u.Active })
+//	err := ToCSV(os.Stdout, active)
+//	// id,name
+//	// 1,Alice
+//	// 3,Carol
+func ToCSV[T any](w io.Writer, rows []T) error {
+	codec, err := csvCodecFor(reflect.TypeFor[T]())
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(Map(codec.fields, func(f csvField) string { return f.name })); err != nil {
+		return err
+	}
+	record := make([]string, len(codec.fields))
+	for i := range rows {
+		row := reflect.ValueOf(&rows[i]).Elem()
+		for j, f := range codec.fields {
+			field, ok := csvFieldByIndex(row, f.index, false)
+			if !ok {
+				record[j] = ""
+				continue
+			}
+			if record[j], err = f.format(field); err != nil {
+				return fmt.Errorf("goassist: CSV row %d, column %q: %w", i, f.name, err)
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// FromCSV reads CSV with a header row into a slice of structs, using the same column names as ToCSV.
+// Columns are matched by name, so their order does not matter; columns without a matching field
+// are ignored and fields without a column keep their zero value. An empty cell leaves the field
+// at its zero value, or nil for pointers. Nil embedded struct pointers are allocated when
+// one of their columns has a value.
+//
+// Example:
+//
+//	users, err := FromCSV[User](strings.NewReader("name,id\nAlice,1\nBob,2\n"))
+//	// users is []User{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob"}}
+func FromCSV[T any](r io.Reader) ([]T, error) {
+	codec, err := csvCodecFor(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err == io.EOF {
+		return []T{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]*csvField, len(header))
+	for i, name := range header {
+		columns[i] = codec.byName[name]
+	}
+
+	result := make([]T, 0)
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var v T
+		row := reflect.ValueOf(&v).Elem()
+		for i, cell := range record {
+			f := columns[i]
+			if f == nil || cell == "" {
+				continue
+			}
+			field, _ := csvFieldByIndex(row, f.index, true)
+			if err := f.parse(field, cell); err != nil {
+				line, _ := cr.FieldPos(i)
+				return nil, fmt.Errorf("goassist: CSV line %d, column %q: %w", line, f.name, err)
+			}
+		}
+		result = append(result, v)
+	}
+}
+
+// csvCodec describes the columns of a struct type. Codecs are built once per type and cached.
+type csvCodec struct {
+	fields []csvField
+	byName map[string]*csvField
+}
+
+type csvField struct {
+	name  string
+	index []int
+	// typ is the field type with any pointer removed.
+	typ     reflect.Type
+	pointer bool
+	text    bool
+}
+
+var (
+	csvCodecs           sync.Map // reflect.Type -> *csvCodec
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+func csvCodecFor(t reflect.Type) (*csvCodec, error) {
+	if c, ok := csvCodecs.Load(t); ok {
+		return c.(*csvCodec), nil
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %v is not a struct", ErrCSVType, t)
+	}
+
+	codec := &csvCodec{byName: make(map[string]*csvField)}
+	if err := codec.addFields(t, nil, map[reflect.Type]bool{t: true}); err != nil {
+		return nil, err
+	}
+	for i := range codec.fields {
+		name := codec.fields[i].name
+		if _, ok := codec.byName[name]; ok {
+			return nil, fmt.Errorf("%w: duplicate column %q in %v", ErrCSVType, name, t)
+		}
+		codec.byName[name] = &codec.fields[i]
+	}
+	c, _ := csvCodecs.LoadOrStore(t, codec)
+	return c.(*csvCodec), nil
+}
+
+// addFields appends the columns of struct type t, whose fields are reached through index.
+// expanding holds the embedded struct types already being expanded, so that a type embedding
+// a pointer to itself does not recurse forever.
+func (c *csvCodec) addFields(t reflect.Type, index []int, expanding map[reflect.Type]bool) error {
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("csv")
+		// Fields of an unexported embedded struct are promoted and still usable, as in encoding/json.
+		// An unexported embedded struct pointer cannot be allocated, so it is skipped like there.
+		embedded := sf.Anonymous && (sf.Type.Kind() == reflect.Struct ||
+			sf.IsExported() && sf.Type.Kind() == reflect.Pointer && sf.Type.Elem().Kind() == reflect.Struct)
+		if (!sf.IsExported() && !embedded) || tag == "-" {
+			continue
+		}
+
+		f := csvField{name: sf.Name, index: append(Clone(index), i), typ: sf.Type}
+		if tag != "" {
+			f.name = tag
+		}
+		if f.typ.Kind() == reflect.Pointer {
+			f.typ, f.pointer = f.typ.Elem(), true
+		}
+		ptr := reflect.PointerTo(f.typ)
+		f.text = ptr.Implements(textMarshalerType) && ptr.Implements(textUnmarshalerType)
+
+		if embedded && !f.text && tag == "" {
+			if expanding[f.typ] {
+				continue
+			}
+			expanding[f.typ] = true
+			err := c.addFields(f.typ, f.index, expanding)
+			delete(expanding, f.typ)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if !f.text && !csvKindSupported(f.typ.Kind()) {
+			return fmt.Errorf("%w: unsupported type %v for field %s", ErrCSVType, sf.Type, sf.Name)
+		}
+		c.fields = append(c.fields, f)
+	}
+	return nil
+}
+
+// csvFieldByIndex is like reflect.Value.FieldByIndex, but steps through embedded struct pointers.
+// A nil pointer on the way is allocated if alloc is set; otherwise csvFieldByIndex reports false.
+func csvFieldByIndex(v reflect.Value, index []int, alloc bool) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !alloc {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+func csvKindSupported(k reflect.Kind) bool {
+	switch k {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func (f *csvField) format(v reflect.Value) (string, error) {
+	if f.pointer {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	if f.text {
+		text, err := v.Addr().Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	default:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	}
+}
+
+func (f *csvField) parse(v reflect.Value, cell string) error {
+	if f.pointer {
+		v.Set(reflect.New(f.typ))
+		v = v.Elem()
+	}
+	if f.text {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(cell))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(cell)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(cell)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(cell, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(cell, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	default:
+		n, err := strconv.ParseFloat(cell, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	}
+	return nil
+}
@@ -0,0 +1,324 @@
+package goassist
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Metric selects how FuzzyFind, RankByDistance and NGramIndex compare strings.
+// Every metric is normalized to a similarity between 0 (nothing in common) and 1 (equal).
+type Metric int
+
+const (
+	// MetricLevenshtein is 1 - LevenshteinDistance / length of the longer string.
+	MetricLevenshtein Metric = iota
+	// MetricDamerau is like MetricLevenshtein but counts adjacent transpositions as one edit.
+	MetricDamerau
+	// MetricJaroWinkler is JaroWinklerSimilarity, which favors strings sharing a prefix.
+	MetricJaroWinkler
+	// MetricTrigram is TrigramSimilarity, which is robust to reordered words.
+	MetricTrigram
+)
+
+// Similarity compares two strings with the metric.
+func (m Metric) Similarity(a, b string) float64 {
+	switch m {
+	case MetricDamerau:
+		return editSimilarity(a, b, DamerauLevenshteinDistance(a, b))
+	case MetricJaroWinkler:
+		return JaroWinklerSimilarity(a, b)
+	case MetricTrigram:
+		return TrigramSimilarity(a, b)
+	default:
+		return editSimilarity(a, b, LevenshteinDistance(a, b))
+	}
+}
+
+func editSimilarity(a, b string, distance int) float64 {
+	longest := max(utf8.RuneCountInString(a), utf8.RuneCountInString(b))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(distance)/float64(longest)
+}
+
+// FuzzyMatch is an element found by fuzzy search, with its index in the searched slice.
+type FuzzyMatch struct {
+	Value string
+	Index int
+	Score float64
+}
+
+// FuzzyOptions configures FuzzyFind and NGramIndex.Search.
+type FuzzyOptions struct {
+	// Metric is the similarity used to score elements.
+	Metric Metric
+	// Threshold is the minimum score of a match.
+	Threshold float64
+	// Limit is the maximum number of matches returned. Zero means no limit.
+	Limit int
+}
+
+// LevenshteinDistance returns the minimum number of single-rune insertions, deletions and
+// substitutions needed to turn a into b.
+//
+// Example:
+//
+//	d := LevenshteinDistance("kitten", "sitting")
+//	// d is 3
+func LevenshteinDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// DamerauLevenshteinDistance is like LevenshteinDistance but also counts a transposition of
+// two adjacent runes as a single edit (optimal string alignment distance).
+//
+// Example:
+//
+//	d := DamerauLevenshteinDistance("form", "from")
+//	// d is 1
+func DamerauLevenshteinDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows := [3][]int{make([]int, len(rb)+1), make([]int, len(rb)+1), make([]int, len(rb)+1)}
+	for j := range rows[1] {
+		rows[1][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		before, prev, curr := rows[0], rows[1], rows[2]
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], before[j-2]+1)
+			}
+		}
+		rows[0], rows[1], rows[2] = prev, curr, before
+	}
+	return rows[1][len(rb)]
+}
+
+// JaroWinklerSimilarity returns the Jaro-Winkler similarity of two strings, between 0 and 1.
+// Strings sharing a common prefix of up to four runes score higher.
+//
+// Example:
+//
+//	s := JaroWinklerSimilarity("martha", "marhta")
+//	// s is about 0.961
+func JaroWinklerSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	window := max(max(len(ra), len(rb))/2-1, 0)
+	matchedA := make([]bool, len(ra))
+	matchedB := make([]bool, len(rb))
+	matches := 0
+	for i, r := range ra {
+		for j := max(0, i-window); j < min(len(rb), i+window+1); j++ {
+			if !matchedB[j] && rb[j] == r {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	for i, j := 0, 0; i < len(ra); i++ {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if ra[i] != rb[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(ra), len(rb)) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// TrigramSimilarity returns the share of distinct trigrams the two strings have in common
+// (Jaccard index), between 0 and 1. Strings are padded so that short words still have trigrams.
+//
+// Example:
+//
+//	s := TrigramSimilarity("hello world", "world hello")
+//	// s is about 0.7
+func TrigramSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ga, gb := ngramSet(a, 3), ngramSet(b, 3)
+	common := 0
+	for g := range ga {
+		if _, ok := gb[g]; ok {
+			common++
+		}
+	}
+	union := len(ga) + len(gb) - common
+	if union == 0 {
+		return 0
+	}
+	return float64(common) / float64(union)
+}
+
+// ngrams returns the n-grams of s, padded with n-1 spaces in front and one space behind.
+func ngrams(s string, n int) []string {
+	runes := []rune(strings.Repeat(" ", n-1) + s + " ")
+	result := make([]string, 0, max(len(runes)-n+1, 0))
+	for i := 0; i+n <= len(runes); i++ {
+		result = append(result, string(runes[i:i+n]))
+	}
+	return result
+}
+
+func ngramSet(s string, n int) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, g := range ngrams(s, n) {
+		set[g] = struct{}{}
+	}
+	return set
+}
+
+// RankByDistance scores every element of the slice against query and returns them ordered
+// from most to least similar. Elements with equal scores keep their original order.
+//
+// Example:
+//
+//	commands := []string{"status", "stash", "start", "commit"}
+//	ranked := RankByDistance(commands, "stats", MetricDamerau)
+//	// ranked[0].Value is "status", ranked[3].Value is "commit"
+func RankByDistance(items []string, query string, metric Metric) []FuzzyMatch {
+	return FuzzyFind(items, query, FuzzyOptions{Metric: metric})
+}
+
+// FuzzyFind returns the elements of the slice whose similarity to query reaches opts.Threshold,
+// ordered from most to least similar and limited to opts.Limit matches.
+//
+// Example:
+//
+//	names := []string{"Johnson", "Jonson", "Jameson", "Smith"}
+//	matches := FuzzyFind(names, "Jonsen", FuzzyOptions{
+//		Metric:    MetricJaroWinkler,
+//		Threshold: 0.85,
+//		Limit:     2,
+//	})
+//	// matches[0].Value is "Jonson", matches[1].Value is "Johnson"
+func FuzzyFind(items []string, query string, opts FuzzyOptions) []FuzzyMatch {
+	matches := make([]FuzzyMatch, 0)
+	for i, item := range items {
+		if score := opts.Metric.Similarity(query, item); score >= opts.Threshold {
+			matches = append(matches, FuzzyMatch{Value: item, Index: i, Score: score})
+		}
+	}
+	return topMatches(matches, opts.Limit)
+}
+
+func topMatches(matches []FuzzyMatch, limit int) []FuzzyMatch {
+	SortStableFunc(matches, func(a, b FuzzyMatch) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		default:
+			return a.Index - b.Index
+		}
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = Clip(matches[:limit])
+	}
+	return matches
+}
+
+// NGramIndex speeds up repeated fuzzy searches over a large slice of strings.
+// Only elements sharing at least one n-gram with the query are scored.
+//
+// Example:
+//
+//	index := NewNGramIndex(productNames, 3)
+//	matches := index.Search("iphnoe", FuzzyOptions{Metric: MetricDamerau, Threshold: 0.6, Limit: 5})
+//	// matches holds at most 5 names close to "iphnoe", such as "iphone"
+type NGramIndex struct {
+	n        int
+	items    []string
+	postings map[string][]int
+}
+
+// NewNGramIndex builds an NGramIndex over the slice using n-grams of n runes.
+// Values of n below 1 are treated as 3.
+func NewNGramIndex(items []string, n int) *NGramIndex {
+	if n < 1 {
+		n = 3
+	}
+	index := &NGramIndex{n: n, items: Clone(items), postings: make(map[string][]int)}
+	for i, item := range items {
+		for g := range ngramSet(item, n) {
+			index.postings[g] = append(index.postings[g], i)
+		}
+	}
+	return index
+}
+
+// Len returns the number of indexed strings.
+func (x *NGramIndex) Len() int {
+	return len(x.items)
+}
+
+// Search returns the indexed strings whose similarity to query reaches opts.Threshold,
+// ordered from most to least similar and limited to opts.Limit matches.
+// Strings sharing no n-gram with the query are never returned.
+func (x *NGramIndex) Search(query string, opts FuzzyOptions) []FuzzyMatch {
+	seen := make(map[int]struct{})
+	matches := make([]FuzzyMatch, 0)
+	for g := range ngramSet(query, x.n) {
+		for _, i := range x.postings[g] {
+			if _, ok := seen[i]; ok {
+				continue
+			}
+			seen[i] = struct{}{}
+			if score := opts.Metric.Similarity(query, x.items[i]); score >= opts.Threshold {
+				matches = append(matches, FuzzyMatch{Value: x.items[i], Index: i, Score: score})
+			}
+		}
+	}
+	return topMatches(matches, opts.Limit)
+}
@@ -0,0 +1,430 @@
+package goassist
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+	"strings"
+)
+
+// ErrCycle is wrapped by every *CycleError.
+var ErrCycle = errors.New("goassist: graph contains a cycle")
+
+// ErrUndirectedGraph is returned when an operation requires a directed graph.
+var ErrUndirectedGraph = errors.New("goassist: operation requires a directed graph")
+
+// CycleError reports a cycle found in a graph. Path starts and ends with the same node.
+type CycleError[K comparable] struct {
+	Path []K
+}
+
+func (e *CycleError[K]) Error() string {
+	parts := Map(e.Path, func(k K) string {
+		return fmt.Sprint(k)
+	})
+	return ErrCycle.Error() + ": " + strings.Join(parts, " -> ")
+}
+
+func (e *CycleError[K]) Unwrap() error {
+	return ErrCycle
+}
+
+// Edge is a connection between two nodes of a Graph. Weight is only used by ShortestPath.
+type Edge[K comparable] struct {
+	From   K
+	To     K
+	Weight float64
+}
+
+// Graph is an adjacency-list graph over comparable node keys.
+// Nodes and neighbors are kept in insertion order, so every traversal is deterministic.
+//
+// Example:
+//
+//	g := NewGraph([]Edge[string]{
+//		{From: "create_users", To: "add_email"},
+//		{From: "add_email", To: "index_email"},
+//	})
+//	order, err := TopologicalSort(g)
+//	// order is []string{"create_users", "add_email", "index_email"}, err is nil
+type Graph[K comparable] struct {
+	directed bool
+	nodes    []K
+	index    map[K]int
+	adj      [][]graphArc
+}
+
+type graphArc struct {
+	to     int
+	weight float64
+}
+
+// NewGraph creates a directed graph from the slice of edges.
+func NewGraph[K comparable](edges []Edge[K]) *Graph[K] {
+	return newGraph(true, edges)
+}
+
+// NewUndirectedGraph creates an undirected graph from the slice of edges.
+func NewUndirectedGraph[K comparable](edges []Edge[K]) *Graph[K] {
+	return newGraph(false, edges)
+}
+
+func newGraph[K comparable](directed bool, edges []Edge[K]) *Graph[K] {
+	g := &Graph[K]{directed: directed, nodes: make([]K, 0), index: make(map[K]int)}
+	for _, e := range edges {
+		g.AddEdge(e.From, e.To, e.Weight)
+	}
+	return g
+}
+
+// Directed reports whether the graph is directed.
+func (g *Graph[K]) Directed() bool {
+	return g.directed
+}
+
+// Len returns the number of nodes in the graph.
+func (g *Graph[K]) Len() int {
+	return len(g.nodes)
+}
+
+// AddNode adds k to the graph if it is not present yet.
+func (g *Graph[K]) AddNode(k K) {
+	g.node(k)
+}
+
+// AddEdge connects from to to, adding missing nodes. In an undirected graph the edge works both ways.
+func (g *Graph[K]) AddEdge(from, to K, weight float64) {
+	f, t := g.node(from), g.node(to)
+	g.adj[f] = append(g.adj[f], graphArc{to: t, weight: weight})
+	if !g.directed && f != t {
+		g.adj[t] = append(g.adj[t], graphArc{to: f, weight: weight})
+	}
+}
+
+func (g *Graph[K]) node(k K) int {
+	if i, ok := g.index[k]; ok {
+		return i
+	}
+	g.index[k] = len(g.nodes)
+	g.nodes = append(g.nodes, k)
+	g.adj = append(g.adj, nil)
+	return len(g.nodes) - 1
+}
+
+// HasNode reports whether k is a node of the graph.
+func (g *Graph[K]) HasNode(k K) bool {
+	_, ok := g.index[k]
+	return ok
+}
+
+// Nodes returns the nodes of the graph in insertion order.
+func (g *Graph[K]) Nodes() []K {
+	return Clone(g.nodes)
+}
+
+// Neighbors returns the nodes reachable from k over a single edge, in insertion order.
+func (g *Graph[K]) Neighbors(k K) []K {
+	i, ok := g.index[k]
+	if !ok {
+		return make([]K, 0)
+	}
+	return Map(g.adj[i], func(a graphArc) K {
+		return g.nodes[a.to]
+	})
+}
+
+// BFS returns an iterator over the nodes reachable from start in breadth-first order.
+//
+// Example:
+//
+//	g := NewGraph([]Edge[int]{{From: 1, To: 2}, {From: 1, To: 3}, {From: 2, To: 4}})
+//	for n := range g.BFS(1) {
+//		fmt.Println(n)
+//	}
+//	// 1, 2, 3, 4
+func (g *Graph[K]) BFS(start K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		s, ok := g.index[start]
+		if !ok {
+			return
+		}
+		visited := make([]bool, len(g.nodes))
+		visited[s] = true
+		queue := []int{s}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			if !yield(g.nodes[u]) {
+				return
+			}
+			for _, a := range g.adj[u] {
+				if !visited[a.to] {
+					visited[a.to] = true
+					queue = append(queue, a.to)
+				}
+			}
+		}
+	}
+}
+
+// DFS returns an iterator over the nodes reachable from start in depth-first preorder.
+//
+// Example:
+//
+//	g := NewGraph([]Edge[int]{{From: 1, To: 2}, {From: 1, To: 3}, {From: 2, To: 4}})
+//	for n := range g.DFS(1) {
+//		fmt.Println(n)
+//	}
+//	// 1, 2, 4, 3
+func (g *Graph[K]) DFS(start K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		s, ok := g.index[start]
+		if !ok {
+			return
+		}
+		visited := make([]bool, len(g.nodes))
+		stack := []int{s}
+		for len(stack) > 0 {
+			u := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if visited[u] {
+				continue
+			}
+			visited[u] = true
+			if !yield(g.nodes[u]) {
+				return
+			}
+			// Push in reverse so that neighbors are visited in insertion order.
+			for i := len(g.adj[u]) - 1; i >= 0; i-- {
+				if to := g.adj[u][i].to; !visited[to] {
+					stack = append(stack, to)
+				}
+			}
+		}
+	}
+}
+
+// FindCycle returns a cycle of the graph and a boolean indicating if one exists.
+// The returned path starts and ends with the same node.
+//
+// Example:
+//
+//	g := NewGraph([]Edge[string]{{From: "a", To: "b"}, {From: "b", To: "c"}, {From: "c", To: "a"}})
+//	cycle, found := g.FindCycle()
+//	// cycle is []string{"a", "b", "c", "a"}, found is true
+func (g *Graph[K]) FindCycle() ([]K, bool) {
+	const (
+		white = iota
+		gray
+		black
+	)
+	color := make([]int, len(g.nodes))
+	stack := make([]int, 0)
+
+	var visit func(u, parent int) []int
+	visit = func(u, parent int) []int {
+		color[u] = gray
+		stack = append(stack, u)
+		skippedParent := false
+		for _, a := range g.adj[u] {
+			if !g.directed && a.to == parent && !skippedParent {
+				skippedParent = true
+				continue
+			}
+			switch color[a.to] {
+			case gray:
+				start := len(stack) - 1
+				for stack[start] != a.to {
+					start--
+				}
+				return append(Clone(stack[start:]), a.to)
+			case white:
+				if cycle := visit(a.to, u); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		color[u] = black
+		return nil
+	}
+
+	for u := range g.nodes {
+		if color[u] != white {
+			continue
+		}
+		if cycle := visit(u, -1); cycle != nil {
+			return Map(cycle, func(i int) K {
+				return g.nodes[i]
+			}), true
+		}
+	}
+	return nil, false
+}
+
+// TopologicalSort orders the nodes of a directed graph so that every edge points forward,
+// using Kahn's algorithm. Nodes that could go next are taken in ascending order, so the
+// result is deterministic. A *CycleError is returned if the graph has a cycle.
+//
+// Example:
+//
+//	g := NewGraph([]Edge[string]{
+//		{From: "compile", To: "link"},
+//		{From: "generate", To: "compile"},
+//		{From: "assets", To: "link"},
+//	})
+//	order, err := TopologicalSort(g)
+//	// order is []string{"assets", "generate", "compile", "link"}, err is nil
+func TopologicalSort[K cmp.Ordered](g *Graph[K]) ([]K, error) {
+	return g.TopologicalSortFunc(cmp.Compare[K])
+}
+
+// TopologicalSortFunc is like TopologicalSort but breaks ties between nodes using cmp.
+func (g *Graph[K]) TopologicalSortFunc(cmp func(a, b K) int) ([]K, error) {
+	if !g.directed {
+		return nil, ErrUndirectedGraph
+	}
+
+	inDegree := make([]int, len(g.nodes))
+	for _, arcs := range g.adj {
+		for _, a := range arcs {
+			inDegree[a.to]++
+		}
+	}
+
+	ready := newPriorityQueue(func(a, b int) bool {
+		return cmp(g.nodes[a], g.nodes[b]) < 0
+	})
+	for u, d := range inDegree {
+		if d == 0 {
+			ready.push(u)
+		}
+	}
+
+	result := make([]K, 0, len(g.nodes))
+	for ready.len() > 0 {
+		u := ready.pop()
+		result = append(result, g.nodes[u])
+		for _, a := range g.adj[u] {
+			if inDegree[a.to]--; inDegree[a.to] == 0 {
+				ready.push(a.to)
+			}
+		}
+	}
+
+	if len(result) < len(g.nodes) {
+		cycle, _ := g.FindCycle()
+		return nil, &CycleError[K]{Path: cycle}
+	}
+	return result, nil
+}
+
+// ShortestPath returns the lightest path from one node to another using Dijkstra's algorithm,
+// together with its total weight and a boolean indicating if to is reachable.
+// Edge weights must not be negative.
+//
+// Example:
+//
+//	g := NewUndirectedGraph([]Edge[string]{
+//		{From: "home", To: "park", Weight: 4},
+//		{From: "home", To: "shop", Weight: 1},
+//		{From: "shop", To: "park", Weight: 2},
+//	})
+//	path, dist, found := g.ShortestPath("home", "park")
+//	// path is []string{"home", "shop", "park"}, dist is 3, found is true
+func (g *Graph[K]) ShortestPath(from, to K) ([]K, float64, bool) {
+	s, ok := g.index[from]
+	t, ok2 := g.index[to]
+	if !ok || !ok2 {
+		return nil, 0, false
+	}
+
+	type entry struct {
+		node int
+		dist float64
+	}
+
+	dist := make([]float64, len(g.nodes))
+	prev := make([]int, len(g.nodes))
+	for i := range dist {
+		dist[i], prev[i] = math.Inf(1), -1
+	}
+	dist[s] = 0
+
+	pq := newPriorityQueue(func(a, b entry) bool {
+		return a.dist < b.dist
+	})
+	pq.push(entry{node: s})
+	for pq.len() > 0 {
+		e := pq.pop()
+		if e.dist > dist[e.node] {
+			continue
+		}
+		if e.node == t {
+			break
+		}
+		for _, a := range g.adj[e.node] {
+			if d := e.dist + a.weight; d < dist[a.to] {
+				dist[a.to], prev[a.to] = d, e.node
+				pq.push(entry{node: a.to, dist: d})
+			}
+		}
+	}
+
+	if math.IsInf(dist[t], 1) {
+		return nil, 0, false
+	}
+
+	path := make([]K, 0)
+	for u := t; u != -1; u = prev[u] {
+		path = append(path, g.nodes[u])
+	}
+	Reverse(path)
+	return path, dist[t], true
+}
+
+// Components returns the connected components of the graph. Edge direction is ignored,
+// so for directed graphs these are the weakly connected components. Components are
+// ordered by their first node, and nodes within a component keep insertion order.
+//
+// Example:
+//
+//	g := NewUndirectedGraph([]Edge[int]{{From: 1, To: 2}, {From: 3, To: 4}, {From: 2, To: 5}})
+//	groups := g.Components()
+//	// groups is [][]int{{1, 2, 5}, {3, 4}}
+func (g *Graph[K]) Components() [][]K {
+	parent := make([]int, len(g.nodes))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for u, arcs := range g.adj {
+		for _, a := range arcs {
+			if ru, rv := find(u), find(a.to); ru != rv {
+				parent[max(ru, rv)] = min(ru, rv)
+			}
+		}
+	}
+
+	result := make([][]K, 0)
+	position := make(map[int]int)
+	for u, k := range g.nodes {
+		root := find(u)
+		i, ok := position[root]
+		if !ok {
+			i = len(result)
+			position[root] = i
+			result = append(result, make([]K, 0))
+		}
+		result[i] = append(result[i], k)
+	}
+	return result
+}
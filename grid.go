@@ -0,0 +1,306 @@
+package goassist
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// ErrRaggedRows is returned by GridOf when the rows have different lengths.
+var ErrRaggedRows = errors.New("goassist: rows have different lengths")
+
+// Cell is the position of a cell in a Grid.
+type Cell struct {
+	Row, Col int
+}
+
+// Connectivity selects which cells count as neighbors of a cell in a Grid.
+type Connectivity int
+
+const (
+	// FourConnected includes the cells above, below, left and right.
+	FourConnected Connectivity = 4
+	// EightConnected also includes the four diagonal cells.
+	EightConnected Connectivity = 8
+)
+
+// gridOffsets lists the neighbor offsets in row-major order.
+var gridOffsets = [...]Cell{{-1, -1}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, -1}, {1, 0}, {1, 1}}
+
+// Grid is a two-dimensional matrix of fixed size, stored row by row in a single slice.
+// Compared to [][]T, it needs one allocation instead of one per row, and its rows cannot
+// end up with different lengths.
+//
+// The zero value is an empty 0x0 grid.
+//
+// Example:
+//
+//	board := NewGrid[rune](3, 3)
+//	board.Set(1, 1, 'X')
+//	board.Set(0, 2, 'O')
+//	center := board.Get(1, 1)
+//	// center is 'X'
+type Grid[T any] struct {
+	rows, cols int
+	cells      []T
+}
+
+// NewGrid creates a grid with the given number of rows and columns, filled with zero values.
+// It panics if rows or cols is negative.
+func NewGrid[T any](rows, cols int) *Grid[T] {
+	if rows < 0 || cols < 0 {
+		panic(fmt.Sprintf("goassist.NewGrid: negative size %dx%d", rows, cols))
+	}
+	return &Grid[T]{rows: rows, cols: cols, cells: make([]T, rows*cols)}
+}
+
+// GridOf creates a grid holding a copy of the rows. It returns an error wrapping ErrRaggedRows
+// if the rows have different lengths.
+//
+// Example:
+//
+//	g, err := GridOf([][]int{
+//		{1, 2, 3},
+//		{4, 5, 6},
+//	})
+//	// g.Rows() is 2, g.Cols() is 3, err is nil
+func GridOf[T any](rows [][]T) (*Grid[T], error) {
+	cols := 0
+	if len(rows) > 0 {
+		cols = len(rows[0])
+	}
+	g := NewGrid[T](len(rows), cols)
+	for r, row := range rows {
+		if len(row) != cols {
+			return nil, fmt.Errorf("%w: row %d has length %d, expected %d", ErrRaggedRows, r, len(row), cols)
+		}
+		copy(g.cells[r*cols:], row)
+	}
+	return g, nil
+}
+
+// Rows returns the number of rows.
+func (g *Grid[T]) Rows() int {
+	return g.rows
+}
+
+// Cols returns the number of columns.
+func (g *Grid[T]) Cols() int {
+	return g.cols
+}
+
+// InBounds reports whether (row, col) is a cell of the grid.
+func (g *Grid[T]) InBounds(row, col int) bool {
+	return row >= 0 && row < g.rows && col >= 0 && col < g.cols
+}
+
+// Get returns the value at (row, col). It panics if the cell is out of bounds.
+func (g *Grid[T]) Get(row, col int) T {
+	return g.cells[g.offset(row, col)]
+}
+
+// Set replaces the value at (row, col). It panics if the cell is out of bounds.
+func (g *Grid[T]) Set(row, col int, v T) {
+	g.cells[g.offset(row, col)] = v
+}
+
+func (g *Grid[T]) offset(row, col int) int {
+	if !g.InBounds(row, col) {
+		panic(fmt.Sprintf("goassist.Grid: cell (%d, %d) out of range for %dx%d grid", row, col, g.rows, g.cols))
+	}
+	return row*g.cols + col
+}
+
+// Values returns the cells in row-major order. The slice shares memory with the grid,
+// so changing it changes the grid.
+func (g *Grid[T]) Values() []T {
+	return g.cells
+}
+
+// Row returns an iterator over the column indexes and values of a row.
+// It panics if row is out of range.
+//
+// Example:
+//
+//	for col, v := range g.Row(0) {
+//		fmt.Println(col, v)
+//	}
+func (g *Grid[T]) Row(row int) iter.Seq2[int, T] {
+	if row < 0 || row >= g.rows {
+		panic(fmt.Sprintf("goassist.Grid: row %d out of range with %d rows", row, g.rows))
+	}
+	return func(yield func(int, T) bool) {
+		for col, v := range g.cells[row*g.cols : (row+1)*g.cols] {
+			if !yield(col, v) {
+				return
+			}
+		}
+	}
+}
+
+// Col returns an iterator over the row indexes and values of a column.
+// It panics if col is out of range.
+func (g *Grid[T]) Col(col int) iter.Seq2[int, T] {
+	if col < 0 || col >= g.cols {
+		panic(fmt.Sprintf("goassist.Grid: column %d out of range with %d columns", col, g.cols))
+	}
+	return func(yield func(int, T) bool) {
+		for row := range g.rows {
+			if !yield(row, g.cells[row*g.cols+col]) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the cells and values of the grid, in row-major order.
+func (g *Grid[T]) All() iter.Seq2[Cell, T] {
+	return func(yield func(Cell, T) bool) {
+		for i, v := range g.cells {
+			if !yield(Cell{Row: i / g.cols, Col: i % g.cols}, v) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors returns an iterator over the in-bounds neighbors of (row, col), in row-major order.
+//
+// Example:
+//
+//	g := NewGrid[int](3, 3)
+//	corner := slices.Collect(g.Neighbors(0, 0, FourConnected))
+//	// corner is []Cell{{0, 1}, {1, 0}}
+func (g *Grid[T]) Neighbors(row, col int, conn Connectivity) iter.Seq[Cell] {
+	return func(yield func(Cell) bool) {
+		for _, d := range gridOffsets {
+			if conn != EightConnected && d.Row != 0 && d.Col != 0 {
+				continue
+			}
+			n := Cell{Row: row + d.Row, Col: col + d.Col}
+			if g.InBounds(n.Row, n.Col) && !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// Region returns the cells connected to (row, col) through cells whose values satisfy match,
+// in breadth-first order starting with (row, col) itself. It returns nil if the cell is out of
+// bounds or its value does not satisfy match.
+//
+// Example:
+//
+//	g, _ := GridOf([][]byte{
+//		[]byte("##."),
+//		[]byte("#.."),
+//		[]byte("..#"),
+//	})
+//	walls := g.Region(0, 0, FourConnected, func(b byte) bool { return b == '#' })
+//	// walls is []Cell{{0, 0}, {0, 1}, {1, 0}}
+func (g *Grid[T]) Region(row, col int, conn Connectivity, match func(T) bool) []Cell {
+	if !g.InBounds(row, col) || !match(g.Get(row, col)) {
+		return nil
+	}
+	visited := NewBitSet(len(g.cells))
+	visited.Set(row*g.cols + col)
+	region := []Cell{{Row: row, Col: col}}
+	for i := 0; i < len(region); i++ {
+		for n := range g.Neighbors(region[i].Row, region[i].Col, conn) {
+			offset := n.Row*g.cols + n.Col
+			if visited.Test(offset) || !match(g.cells[offset]) {
+				continue
+			}
+			visited.Set(offset)
+			region = append(region, n)
+		}
+	}
+	return region
+}
+
+// FloodFill sets every cell of the Region of (row, col) to fill and returns the number of cells changed.
+//
+// Example:
+//
+//	image, _ := GridOf([][]int{
+//		{0, 0, 1},
+//		{0, 1, 1},
+//		{1, 0, 0},
+//	})
+//	filled := image.FloodFill(0, 0, FourConnected, 7, func(v int) bool { return v == 0 })
+//	// filled is 3, image.ToSlices() is [][]int{{7, 7, 1}, {7, 1, 1}, {1, 0, 0}}
+func (g *Grid[T]) FloodFill(row, col int, conn Connectivity, fill T, match func(T) bool) int {
+	region := g.Region(row, col, conn, match)
+	for _, c := range region {
+		g.cells[c.Row*g.cols+c.Col] = fill
+	}
+	return len(region)
+}
+
+// Clone returns a copy of the grid.
+func (g *Grid[T]) Clone() *Grid[T] {
+	return &Grid[T]{rows: g.rows, cols: g.cols, cells: Clone(g.cells)}
+}
+
+// Transpose returns a new grid with rows and columns swapped.
+//
+// Example:
+//
+//	g, _ := GridOf([][]int{{1, 2, 3}, {4, 5, 6}})
+//	t := g.Transpose()
+//	// t.ToSlices() is [][]int{{1, 4}, {2, 5}, {3, 6}}
+func (g *Grid[T]) Transpose() *Grid[T] {
+	t := NewGrid[T](g.cols, g.rows)
+	for i, v := range g.cells {
+		row, col := i/g.cols, i%g.cols
+		t.cells[col*t.cols+row] = v
+	}
+	return t
+}
+
+// Rotate returns a new grid rotated clockwise by turns quarter turns.
+// Negative turns rotate counterclockwise.
+//
+// Example:
+//
+//	g, _ := GridOf([][]int{{1, 2, 3}, {4, 5, 6}})
+//	r := g.Rotate(1)
+//	// r.ToSlices() is [][]int{{4, 1}, {5, 2}, {6, 3}}
+func (g *Grid[T]) Rotate(turns int) *Grid[T] {
+	switch (turns%4 + 4) % 4 {
+	case 1:
+		r := NewGrid[T](g.cols, g.rows)
+		for i, v := range g.cells {
+			row, col := i/g.cols, i%g.cols
+			r.cells[col*r.cols+(g.rows-1-row)] = v
+		}
+		return r
+	case 2:
+		r := g.Clone()
+		Reverse(r.cells)
+		return r
+	case 3:
+		r := NewGrid[T](g.cols, g.rows)
+		for i, v := range g.cells {
+			row, col := i/g.cols, i%g.cols
+			r.cells[(g.cols-1-col)*r.cols+row] = v
+		}
+		return r
+	default:
+		return g.Clone()
+	}
+}
+
+// ToSlices returns the grid as a new slice of rows.
+func (g *Grid[T]) ToSlices() [][]T {
+	result := make([][]T, g.rows)
+	for r := range result {
+		result[r] = Clone(g.cells[r*g.cols : (r+1)*g.cols])
+	}
+	return result
+}
+
+// String formats the grid like a slice of rows.
+func (g *Grid[T]) String() string {
+	return fmt.Sprint(g.ToSlices())
+}
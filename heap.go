@@ -0,0 +1,62 @@
+package goassist
+
+// priorityQueue is a binary min-heap ordered by less.
+type priorityQueue[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func newPriorityQueue[T any](less func(a, b T) bool) *priorityQueue[T] {
+	return &priorityQueue[T]{items: make([]T, 0), less: less}
+}
+
+func (q *priorityQueue[T]) len() int {
+	return len(q.items)
+}
+
+func (q *priorityQueue[T]) push(v T) {
+	q.items = append(q.items, v)
+	q.up(len(q.items) - 1)
+}
+
+func (q *priorityQueue[T]) pop() T {
+	top := q.items[0]
+	last := len(q.items) - 1
+	q.items[0] = q.items[last]
+
+	var zero T
+
+	q.items[last] = zero
+	q.items = q.items[:last]
+	q.down(0)
+	return top
+}
+
+func (q *priorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !q.less(q.items[i], q.items[parent]) {
+			return
+		}
+		q.items[i], q.items[parent] = q.items[parent], q.items[i]
+		i = parent
+	}
+}
+
+func (q *priorityQueue[T]) down(i int) {
+	n := len(q.items)
+	for {
+		smallest := i
+		if l := 2*i + 1; l < n && q.less(q.items[l], q.items[smallest]) {
+			smallest = l
+		}
+		if r := 2*i + 2; r < n && q.less(q.items[r], q.items[smallest]) {
+			smallest = r
+		}
+		if smallest == i {
+			return
+		}
+		q.items[i], q.items[smallest] = q.items[smallest], q.items[i]
+		i = smallest
+	}
+}
@@ -2,9 +2,15 @@ package goassist
 
 import (
 	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
 	"slices"
 )
 
+// ErrDeepFlattenType is returned by DeepFlatten when a value is neither a T nor a slice or array.
+var ErrDeepFlattenType = errors.New("goassist: DeepFlatten")
+
 // Map applies a function to each element of the input slice and returns a new slice with the results.
 //
 // Example:
@@ -53,6 +59,61 @@ func Filter[T any](arr []T, fn func(T) bool) []T {
 	return result
 }
 
+// MapInto is like Map but appends the results to dst and returns the extended slice.
+// When dst has enough spare capacity, it does not allocate, so a buffer can be reused
+// across calls by passing dst[:0].
+//
+// Example:
+//
+//	buf := make([]int, 0, 64)
+//	for _, batch := range batches {
+//		buf = MapInto(buf[:0], batch, func(r Row) int { return r.ID })
+//		process(buf)
+//	}
+func MapInto[T any, R any](dst []R, arr []T, fn func(T) R) []R {
+	dst = Grow(dst, len(arr))
+	for _, v := range arr {
+		dst = append(dst, fn(v))
+	}
+	return dst
+}
+
+// FilterInto is like Filter but appends the matching elements to dst and returns the extended slice.
+// When dst has enough spare capacity, it does not allocate.
+//
+// Example:
+//
+//	buf := make([]int, 0, 64)
+//	buf = FilterInto(buf[:0], []int{1, 2, 3, 4}, func(x int) bool {
+//		return x%2 == 0
+//	})
+//	// buf is []int{2, 4}
+func FilterInto[T any](dst []T, arr []T, fn func(T) bool) []T {
+	for _, v := range arr {
+		if fn(v) {
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
+
+// FilterInPlace keeps only the elements that satisfy the predicate function, reusing the backing
+// array of the input instead of allocating. The input slice must not be used afterwards; the
+// elements between the new and the old length are zeroed, like DeleteFunc does.
+//
+// Example:
+//
+//	numbers := []int{1, 2, 3, 4, 5}
+//	numbers = FilterInPlace(numbers, func(x int) bool {
+//		return x%2 == 0
+//	})
+//	// numbers is []int{2, 4}
+func FilterInPlace[S ~[]E, E any](s S, fn func(E) bool) S {
+	return DeleteFunc(s, func(v E) bool {
+		return !fn(v)
+	})
+}
+
 // Reduce applies a function cumulatively to the elements of the slice, reducing it to a single value.
 //
 // Example:
@@ -168,6 +229,103 @@ func Flatten[T any](arr [][]T) []T {
 	return result
 }
 
+// Flatten3 flattens a slice of slices of slices into a single slice.
+//
+// Example:
+//
+//	cube := [][][]int{{{1, 2}, {3}}, {{4}, {5, 6}}}
+//	flat := Flatten3(cube)
+//	// flat is []int{1, 2, 3, 4, 5, 6}
+func Flatten3[T any](arr [][][]T) []T {
+	result := make([]T, 0)
+	for _, v := range arr {
+		result = append(result, Flatten(v)...)
+	}
+	return result
+}
+
+// FlatMap applies a function returning a slice to each element of the input slice
+// and concatenates the results.
+//
+// Example:
+//
+//	sentences := []string{"hello world", "go assist"}
+//	words := FlatMap(sentences, strings.Fields)
+//	// words is []string{"hello", "world", "go", "assist"}
+func FlatMap[T any, R any](arr []T, fn func(T) []R) []R {
+	result := make([]R, 0)
+	for _, v := range arr {
+		result = append(result, fn(v)...)
+	}
+	return result
+}
+
+// DeepFlatten flattens arbitrarily nested slices and arrays into a single slice of T.
+// Values assignable to T are collected as they are, slices, arrays and interfaces holding
+// them are descended into, and any other value yields an error wrapping ErrDeepFlattenType.
+// When T is an interface type such as any, slices and arrays are always descended into
+// rather than collected whole, and nil values are collected as a nil T; for any other T
+// they yield an error.
+//
+// Example:
+//
+//	nested := []any{1, []int{2, 3}, [][]int{{4}, {5, 6}}, [2]int{7, 8}}
+//	flat, err := DeepFlatten[int](nested)
+//	// flat is []int{1, 2, 3, 4, 5, 6, 7, 8}, err is nil
+//
+//	_, err = DeepFlatten[int]([]any{1, "two"})
+//	// err is "goassist: DeepFlatten: cannot use string as int at [1]"
+func DeepFlatten[T any](v any) ([]T, error) {
+	result := make([]T, 0)
+	target := reflect.TypeFor[T]()
+	if err := deepFlatten(reflect.ValueOf(v), target, "", &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func deepFlatten[T any](v reflect.Value, target reflect.Type, path string, result *[]T) error {
+	for v.IsValid() && v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	// A nil interface has no dynamic type to check, so it is handled like an untyped nil.
+	if !v.IsValid() || v.Kind() == reflect.Interface {
+		if target.Kind() == reflect.Interface {
+			var zero T
+			*result = append(*result, zero)
+			return nil
+		}
+		return fmt.Errorf("%w: cannot use nil as %v at %s", ErrDeepFlattenType, target, pathOrRoot(path))
+	}
+
+	// Every value is assignable to an interface target, so check for nesting first in that case.
+	nested := v.Kind() == reflect.Slice || v.Kind() == reflect.Array
+	if v.Type().AssignableTo(target) && !(nested && target.Kind() == reflect.Interface) {
+		*result = append(*result, v.Interface().(T))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if err := deepFlatten(v.Index(i), target, fmt.Sprintf("%s[%d]", path, i), result); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: cannot use %v as %v at %s", ErrDeepFlattenType, v.Type(), target, pathOrRoot(path))
+	}
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "root"
+	}
+	return path
+}
+
 // Zip combines two slices into a slice of pairs. If the input slices have different lengths,
 // the result will have the length of the shorter slice.
 //
@@ -178,8 +336,9 @@ func Flatten[T any](arr [][]T) []T {
 //	pairs := Zip(numbers, letters)
 //	// pairs is [][]int{{1, 'a'}, {2, 'b'}, {3, 'c'}}
 func Zip[T any, R any](arr []T, arr2 []R) [][]any {
-	result := make([][]any, 0)
-	for i := range arr {
+	n := min(len(arr), len(arr2))
+	result := make([][]any, 0, n)
+	for i := range n {
 		result = append(result, []any{arr[i], arr2[i]})
 	}
 	return result
@@ -495,6 +654,8 @@ func IsSortedFunc[S ~[]E, E any](x S, cmp func(a, b E) int) bool {
 }
 
 // Max returns the maximum element in x. It panics if x is empty.
+// For floating-point elements, a NaN anywhere in x makes the result NaN.
+// []float64, []float32, []int64 and []int32 use an unrolled kernel.
 //
 // Example:
 //
@@ -502,6 +663,11 @@ func IsSortedFunc[S ~[]E, E any](x S, cmp func(a, b E) int) bool {
 //	max := Max(numbers)
 //	// max is 5
 func Max[S ~[]E, E cmp.Ordered](x S) E {
+	if len(x) > 0 {
+		if m, ok := maxKernelDispatch([]E(x)); ok {
+			return m
+		}
+	}
 	return slices.Max(x)
 }
 
@@ -527,6 +693,8 @@ func MaxFunc[S ~[]E, E any](x S, cmp func(a, b E) int) E {
 }
 
 // Min returns the minimum element in x. It panics if x is empty.
+// For floating-point elements, a NaN anywhere in x makes the result NaN.
+// []float64, []float32, []int64 and []int32 use an unrolled kernel.
 //
 // Example:
 //
@@ -534,6 +702,11 @@ func MaxFunc[S ~[]E, E any](x S, cmp func(a, b E) int) E {
 //	min := Min(numbers)
 //	// min is 1
 func Min[S ~[]E, E cmp.Ordered](x S) E {
+	if len(x) > 0 {
+		if m, ok := minKernelDispatch([]E(x)); ok {
+			return m
+		}
+	}
 	return slices.Min(x)
 }
 
@@ -634,3 +807,88 @@ func SortFunc[S ~[]E, E any](x S, cmp func(a, b E) int) {
 func SortStableFunc[S ~[]E, E any](x S, cmp func(a, b E) int) {
 	slices.SortStableFunc(x, cmp)
 }
+
+// Sorted returns a sorted copy of the slice in ascending order, leaving the input unchanged.
+//
+// Example:
+//
+//	numbers := []int{3, 1, 4, 1, 5, 9}
+//	sorted := Sorted(numbers)
+//	// sorted is []int{1, 1, 3, 4, 5, 9}, numbers is unchanged
+func Sorted[S ~[]E, E cmp.Ordered](x S) S {
+	result := Clone(x)
+	Sort(result)
+	return result
+}
+
+// SortedFunc returns a copy of the slice sorted using a custom comparison function,
+// leaving the input unchanged.
+//
+// Example:
+//
+//	type Person struct {
+//		Name string
+//		Age  int
+//	}
+//	people := []Person{
+//		{"Charlie", 35},
+//		{"Alice", 25},
+//	}
+//	byAge := SortedFunc(people, func(a, b Person) int {
+//		return a.Age - b.Age
+//	})
+//	// byAge[0] is Person{"Alice", 25}, people is unchanged
+func SortedFunc[S ~[]E, E any](x S, cmp func(a, b E) int) S {
+	result := Clone(x)
+	SortFunc(result, cmp)
+	return result
+}
+
+// Reversed returns a copy of the slice with the elements in reverse order, leaving the input unchanged.
+//
+// Example:
+//
+//	numbers := []int{1, 2, 3}
+//	reversed := Reversed(numbers)
+//	// reversed is []int{3, 2, 1}, numbers is unchanged
+func Reversed[S ~[]E, E any](s S) S {
+	result := Clone(s)
+	Reverse(result)
+	return result
+}
+
+// WithDeleted returns a copy of the slice without the elements between indices i and j,
+// leaving the input unchanged. Like Delete, it panics if s[i:j] is not a valid slice of s.
+//
+// Example:
+//
+//	numbers := []int{1, 2, 3, 4, 5}
+//	rest := WithDeleted(numbers, 1, 3)
+//	// rest is []int{1, 4, 5}, numbers is unchanged
+func WithDeleted[S ~[]E, E any](s S, i, j int) S {
+	return Delete(Clone(s), i, j)
+}
+
+// WithInserted returns a copy of the slice with the values v... inserted at index i,
+// leaving the input unchanged. Like Insert, it panics if i is out of range.
+//
+// Example:
+//
+//	numbers := []int{1, 2, 5}
+//	all := WithInserted(numbers, 2, 3, 4)
+//	// all is []int{1, 2, 3, 4, 5}, numbers is unchanged
+func WithInserted[S ~[]E, E any](s S, i int, v ...E) S {
+	return Insert(Clone(s), i, v...)
+}
+
+// Compacted returns a copy of the slice with consecutive runs of equal elements replaced
+// by a single copy, leaving the input unchanged.
+//
+// Example:
+//
+//	numbers := []int{1, 1, 2, 3, 3, 3, 4}
+//	unique := Compacted(numbers)
+//	// unique is []int{1, 2, 3, 4}, numbers is unchanged
+func Compacted[S ~[]E, E comparable](s S) S {
+	return Compact(Clone(s))
+}
@@ -0,0 +1,278 @@
+package goassist
+
+import "cmp"
+
+// Interval is the half-open range [Start, End). An interval with End <= Start is empty.
+//
+// Example:
+//
+//	meeting := Interval[int]{Start: 9, End: 11}
+//	busy := meeting.Contains(10)
+//	// busy is true
+//	busy = meeting.Contains(11)
+//	// busy is false
+type Interval[T cmp.Ordered] struct {
+	Start T
+	End   T
+}
+
+// IsEmpty reports whether the interval contains no points.
+func (iv Interval[T]) IsEmpty() bool {
+	return iv.End <= iv.Start
+}
+
+// Contains reports whether p lies within the interval.
+func (iv Interval[T]) Contains(p T) bool {
+	return iv.Start <= p && p < iv.End
+}
+
+// Overlaps reports whether the two intervals share at least one point.
+func (iv Interval[T]) Overlaps(other Interval[T]) bool {
+	return iv.Start < other.End && other.Start < iv.End && !iv.IsEmpty() && !other.IsEmpty()
+}
+
+// Intersect returns the common part of the two intervals and a boolean indicating whether it is non-empty.
+//
+// Example:
+//
+//	a := Interval[int]{Start: 1, End: 5}
+//	b := Interval[int]{Start: 3, End: 8}
+//	common, ok := a.Intersect(b)
+//	// common is Interval[int]{Start: 3, End: 5}, ok is true
+func (iv Interval[T]) Intersect(other Interval[T]) (Interval[T], bool) {
+	result := Interval[T]{Start: max(iv.Start, other.Start), End: min(iv.End, other.End)}
+	return result, !result.IsEmpty()
+}
+
+// CompareIntervals orders intervals by Start and then by End. It can be used with SortFunc.
+func CompareIntervals[T cmp.Ordered](a, b Interval[T]) int {
+	if c := cmp.Compare(a.Start, b.Start); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.End, b.End)
+}
+
+// MergeOverlapping returns the union of the intervals as a sorted slice of disjoint intervals.
+// Overlapping and touching intervals are merged, empty intervals are dropped.
+// The input slice is not modified.
+//
+// Example:
+//
+//	busy := []Interval[int]{{9, 10}, {13, 15}, {9, 11}, {11, 12}}
+//	merged := MergeOverlapping(busy)
+//	// merged is []Interval[int]{{9, 12}, {13, 15}}
+func MergeOverlapping[T cmp.Ordered](intervals []Interval[T]) []Interval[T] {
+	sorted := Filter(intervals, func(iv Interval[T]) bool {
+		return !iv.IsEmpty()
+	})
+	SortFunc(sorted, CompareIntervals[T])
+
+	result := make([]Interval[T], 0, len(sorted))
+	for _, iv := range sorted {
+		if last := len(result) - 1; last >= 0 && iv.Start <= result[last].End {
+			result[last].End = max(result[last].End, iv.End)
+			continue
+		}
+		result = append(result, iv)
+	}
+	return result
+}
+
+// Intersections returns the points covered by both interval sets as a sorted slice of disjoint intervals.
+//
+// Example:
+//
+//	alice := []Interval[int]{{9, 12}, {14, 17}}
+//	bob := []Interval[int]{{10, 15}}
+//	both := Intersections(alice, bob)
+//	// both is []Interval[int]{{10, 12}, {14, 15}}
+func Intersections[T cmp.Ordered](a, b []Interval[T]) []Interval[T] {
+	a, b = MergeOverlapping(a), MergeOverlapping(b)
+
+	result := make([]Interval[T], 0)
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		if common, ok := a[i].Intersect(b[j]); ok {
+			result = append(result, common)
+		}
+		if a[i].End < b[j].End {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+// Gaps returns the parts of within that are not covered by any of the intervals,
+// as a sorted slice of disjoint intervals.
+//
+// Example:
+//
+//	busy := []Interval[int]{{9, 10}, {12, 13}}
+//	free := Gaps(busy, Interval[int]{Start: 8, End: 18})
+//	// free is []Interval[int]{{8, 9}, {10, 12}, {13, 18}}
+func Gaps[T cmp.Ordered](intervals []Interval[T], within Interval[T]) []Interval[T] {
+	result := make([]Interval[T], 0)
+	if within.IsEmpty() {
+		return result
+	}
+
+	cursor := within.Start
+	for _, iv := range MergeOverlapping(intervals) {
+		if iv.End <= cursor {
+			continue
+		}
+		if iv.Start >= within.End {
+			break
+		}
+		if iv.Start > cursor {
+			result = append(result, Interval[T]{Start: cursor, End: iv.Start})
+		}
+		cursor = iv.End
+	}
+	if cursor < within.End {
+		result = append(result, Interval[T]{Start: cursor, End: within.End})
+	}
+	return result
+}
+
+// IntervalTree is a static centered interval tree answering stabbing and overlap queries
+// in O(log n + k), where k is the number of reported intervals.
+//
+// Example:
+//
+//	tree := NewIntervalTree([]Interval[int]{{1, 5}, {3, 8}, {10, 12}})
+//	at4 := tree.Stab(4)
+//	// at4 holds {1, 5} and {3, 8}
+//	during := tree.Overlapping(Interval[int]{Start: 7, End: 11})
+//	// during holds {3, 8} and {10, 12}
+type IntervalTree[T cmp.Ordered] struct {
+	root *intervalNode[T]
+	size int
+}
+
+type intervalNode[T cmp.Ordered] struct {
+	center      T
+	byStart     []Interval[T] // intervals containing center, ascending by Start
+	byEnd       []Interval[T] // the same intervals, descending by End
+	left, right *intervalNode[T]
+}
+
+// NewIntervalTree builds an IntervalTree from the slice. Empty intervals are ignored.
+// The input slice is not modified.
+func NewIntervalTree[T cmp.Ordered](intervals []Interval[T]) *IntervalTree[T] {
+	nonEmpty := Filter(intervals, func(iv Interval[T]) bool {
+		return !iv.IsEmpty()
+	})
+	return &IntervalTree[T]{root: buildIntervalNode(nonEmpty), size: len(nonEmpty)}
+}
+
+func buildIntervalNode[T cmp.Ordered](intervals []Interval[T]) *intervalNode[T] {
+	if len(intervals) == 0 {
+		return nil
+	}
+
+	// The median start is contained in at least one interval, so every node is non-empty
+	// and each side holds at most half of the intervals.
+	starts := Map(intervals, func(iv Interval[T]) T {
+		return iv.Start
+	})
+	Sort(starts)
+	center := starts[len(starts)/2]
+
+	var left, right []Interval[T]
+	n := &intervalNode[T]{center: center}
+	for _, iv := range intervals {
+		switch {
+		case iv.End <= center:
+			left = append(left, iv)
+		case iv.Start > center:
+			right = append(right, iv)
+		default:
+			n.byStart = append(n.byStart, iv)
+		}
+	}
+
+	n.byEnd = Clone(n.byStart)
+	SortFunc(n.byStart, func(a, b Interval[T]) int {
+		return cmp.Compare(a.Start, b.Start)
+	})
+	SortFunc(n.byEnd, func(a, b Interval[T]) int {
+		return cmp.Compare(b.End, a.End)
+	})
+	n.left = buildIntervalNode(left)
+	n.right = buildIntervalNode(right)
+
+	return n
+}
+
+// Len returns the number of intervals stored in the tree.
+func (t *IntervalTree[T]) Len() int {
+	return t.size
+}
+
+// Stab returns the intervals containing p, in no particular order.
+func (t *IntervalTree[T]) Stab(p T) []Interval[T] {
+	result := make([]Interval[T], 0)
+	for n := t.root; n != nil; {
+		switch {
+		case p < n.center:
+			for _, iv := range n.byStart {
+				if iv.Start > p {
+					break
+				}
+				result = append(result, iv)
+			}
+			n = n.left
+		case p > n.center:
+			for _, iv := range n.byEnd {
+				if iv.End <= p {
+					break
+				}
+				result = append(result, iv)
+			}
+			n = n.right
+		default:
+			return append(result, n.byStart...)
+		}
+	}
+	return result
+}
+
+// Overlapping returns the intervals sharing at least one point with q, in no particular order.
+func (t *IntervalTree[T]) Overlapping(q Interval[T]) []Interval[T] {
+	result := make([]Interval[T], 0)
+	if q.IsEmpty() {
+		return result
+	}
+	return t.root.overlapping(q, result)
+}
+
+func (n *intervalNode[T]) overlapping(q Interval[T], result []Interval[T]) []Interval[T] {
+	if n == nil {
+		return result
+	}
+
+	switch {
+	case q.End <= n.center:
+		for _, iv := range n.byStart {
+			if iv.Start >= q.End {
+				break
+			}
+			result = append(result, iv)
+		}
+		return n.left.overlapping(q, result)
+	case q.Start > n.center:
+		for _, iv := range n.byEnd {
+			if iv.End <= q.Start {
+				break
+			}
+			result = append(result, iv)
+		}
+		return n.right.overlapping(q, result)
+	default:
+		result = append(result, n.byStart...)
+		result = n.left.overlapping(q, result)
+		return n.right.overlapping(q, result)
+	}
+}
@@ -0,0 +1,114 @@
+package goassist
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// ErrNotJSONArray is returned by DecodeJSONSeq when the input does not start with a JSON array.
+var ErrNotJSONArray = errors.New("goassist: input is not a JSON array")
+
+// DecodeJSONSeq returns an iterator over the elements of a JSON array read from r, decoding one
+// element at a time so that arrays larger than memory can be processed. Decoding stops at the
+// first error, which is yielded with the zero value of T. The sequence reads r and can only be
+// iterated once. Use UntilError to feed the elements to MapSeq, FilterSeq and the other helpers.
+//
+// Example:
+//
+//	for order, err := range DecodeJSONSeq[Order](file) {
+//		if err != nil {
+//			return err
+//		}
+//		total += order.Amount
+//	}
+func DecodeJSONSeq[T any](r io.Reader) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		dec := json.NewDecoder(r)
+
+		tok, err := dec.Token()
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		if tok != json.Delim('[') {
+			yield(zero, fmt.Errorf("%w: found %v", ErrNotJSONArray, tok))
+			return
+		}
+
+		for dec.More() {
+			var v T
+			if err := dec.Decode(&v); err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// JSONSeqFormat selects how EncodeJSONSeq writes a sequence.
+type JSONSeqFormat int
+
+const (
+	// JSONArray writes the sequence as a single JSON array, as json.Marshal would write the
+	// collected slice, except that an empty sequence is written as [] rather than null.
+	JSONArray JSONSeqFormat = iota
+	// JSONLines writes every element as JSON on its own line (NDJSON).
+	JSONLines
+)
+
+// EncodeJSONSeq writes every element of the sequence to w as JSON, in the given format, holding
+// only one element in memory at a time. It stops at the first error.
+//
+// Example:
+//
+//	var err error
+//	orders := UntilError(DecodeJSONSeq[Order](in), &err)
+//	large := FilterSeq(orders, func(o Order) bool { return o.Amount > 1000 })
+//	if werr := EncodeJSONSeq(out, large, JSONLines); werr != nil {
+//		return werr
+//	}
+//	if err != nil {
+//		return err
+//	}
+func EncodeJSONSeq[T any](w io.Writer, seq iter.Seq[T], format JSONSeqFormat) error {
+	open, sep, after, end := "[", ",", "", "]"
+	if format == JSONLines {
+		open, sep, after, end = "", "", "\n", ""
+	}
+
+	// bufio.Writer keeps the first write error and returns it from every later call,
+	// so checking the last write of each element is enough.
+	bw := bufio.NewWriter(w)
+	bw.WriteString(open)
+	first := true
+	for v := range seq {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if !first {
+			bw.WriteString(sep)
+		}
+		first = false
+		bw.Write(data)
+		if _, err := bw.WriteString(after); err != nil {
+			return err
+		}
+	}
+	bw.WriteString(end)
+	return bw.Flush()
+}
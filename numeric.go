@@ -0,0 +1,244 @@
+package goassist
+
+// kernelNumber lists the element types with specialized, unrolled kernels. Other Number types,
+// including named types such as `type Celsius float64`, use plain generic loops.
+//
+// The kernels keep four independent accumulators, so that the CPU can work on several elements
+// at once instead of waiting for each addition to finish.
+type kernelNumber interface {
+	float64 | float32 | int64 | int32
+}
+
+// Sum returns the sum of the elements of the slice, or 0 if it is empty.
+// []float64, []float32, []int64 and []int32 use an unrolled kernel; for floating-point
+// elements the additions are grouped differently than in a simple loop, so the result
+// may differ from it in the last bits.
+//
+// Example:
+//
+//	hours := []float64{1.5, 2.5, 4}
+//	total := Sum(hours)
+//	// total is 8
+func Sum[S ~[]E, E Number](x S) E {
+	switch s := any([]E(x)).(type) {
+	case []float64:
+		return any(sumKernel(s)).(E)
+	case []float32:
+		return any(sumKernel(s)).(E)
+	case []int64:
+		return any(sumKernel(s)).(E)
+	case []int32:
+		return any(sumKernel(s)).(E)
+	}
+
+	var sum E
+	for _, v := range x {
+		sum += v
+	}
+	return sum
+}
+
+// Dot returns the dot product of two vectors: the sum of a[i]*b[i].
+// If the slices have different lengths, the extra elements of the longer one are ignored.
+//
+// Example:
+//
+//	weights := []float64{0.5, 0.25, 0.25}
+//	scores := []float64{80, 60, 100}
+//	weighted := Dot(weights, scores)
+//	// weighted is 80
+func Dot[S ~[]E, E Number](a, b S) E {
+	switch s := any([]E(a)).(type) {
+	case []float64:
+		return any(dotKernel(s, any([]E(b)).([]float64))).(E)
+	case []float32:
+		return any(dotKernel(s, any([]E(b)).([]float32))).(E)
+	case []int64:
+		return any(dotKernel(s, any([]E(b)).([]int64))).(E)
+	case []int32:
+		return any(dotKernel(s, any([]E(b)).([]int32))).(E)
+	}
+
+	var sum E
+	for i := range min(len(a), len(b)) {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+// Scale returns a new slice with every element of x multiplied by factor.
+//
+// Example:
+//
+//	cents := Scale([]int64{1, 25, 100}, 100)
+//	// cents is []int64{100, 2500, 10000}
+func Scale[S ~[]E, E Number](x S, factor E) S {
+	result := make(S, len(x))
+	switch dst := any([]E(result)).(type) {
+	case []float64:
+		scaleKernel(dst, any([]E(x)).([]float64), any(factor).(float64))
+	case []float32:
+		scaleKernel(dst, any([]E(x)).([]float32), any(factor).(float32))
+	case []int64:
+		scaleKernel(dst, any([]E(x)).([]int64), any(factor).(int64))
+	case []int32:
+		scaleKernel(dst, any([]E(x)).([]int32), any(factor).(int32))
+	default:
+		for i, v := range x {
+			result[i] = v * factor
+		}
+	}
+	return result
+}
+
+// Add returns a new slice holding the element-wise sums a[i]+b[i].
+// If the slices have different lengths, the result has the length of the shorter one.
+//
+// Example:
+//
+//	totals := Add([]int32{1, 2, 3}, []int32{10, 20, 30})
+//	// totals is []int32{11, 22, 33}
+func Add[S ~[]E, E Number](a, b S) S {
+	result := make(S, min(len(a), len(b)))
+	switch dst := any([]E(result)).(type) {
+	case []float64:
+		addKernel(dst, any([]E(a)).([]float64), any([]E(b)).([]float64))
+	case []float32:
+		addKernel(dst, any([]E(a)).([]float32), any([]E(b)).([]float32))
+	case []int64:
+		addKernel(dst, any([]E(a)).([]int64), any([]E(b)).([]int64))
+	case []int32:
+		addKernel(dst, any([]E(a)).([]int32), any([]E(b)).([]int32))
+	default:
+		for i := range result {
+			result[i] = a[i] + b[i]
+		}
+	}
+	return result
+}
+
+// minKernelDispatch returns the minimum of a non-empty slice using a specialized kernel,
+// and false if E has none.
+func minKernelDispatch[E any](x []E) (E, bool) {
+	var zero E
+	switch s := any(x).(type) {
+	case []float64:
+		return any(minKernel(s)).(E), true
+	case []float32:
+		return any(minKernel(s)).(E), true
+	case []int64:
+		return any(minKernel(s)).(E), true
+	case []int32:
+		return any(minKernel(s)).(E), true
+	}
+	return zero, false
+}
+
+// maxKernelDispatch returns the maximum of a non-empty slice using a specialized kernel,
+// and false if E has none.
+func maxKernelDispatch[E any](x []E) (E, bool) {
+	var zero E
+	switch s := any(x).(type) {
+	case []float64:
+		return any(maxKernel(s)).(E), true
+	case []float32:
+		return any(maxKernel(s)).(E), true
+	case []int64:
+		return any(maxKernel(s)).(E), true
+	case []int32:
+		return any(maxKernel(s)).(E), true
+	}
+	return zero, false
+}
+
+func sumKernel[E kernelNumber](x []E) E {
+	var s0, s1, s2, s3 E
+	for len(x) >= 4 {
+		s0 += x[0]
+		s1 += x[1]
+		s2 += x[2]
+		s3 += x[3]
+		x = x[4:]
+	}
+	for _, v := range x {
+		s0 += v
+	}
+	return (s0 + s1) + (s2 + s3)
+}
+
+func dotKernel[E kernelNumber](a, b []E) E {
+	n := min(len(a), len(b))
+	a, b = a[:n], b[:n]
+	var s0, s1, s2, s3 E
+	for len(a) >= 4 && len(b) >= 4 {
+		s0 += a[0] * b[0]
+		s1 += a[1] * b[1]
+		s2 += a[2] * b[2]
+		s3 += a[3] * b[3]
+		a, b = a[4:], b[4:]
+	}
+	for i := range a {
+		s0 += a[i] * b[i]
+	}
+	return (s0 + s1) + (s2 + s3)
+}
+
+func scaleKernel[E kernelNumber](dst, x []E, factor E) {
+	dst = dst[:len(x)]
+	for len(x) >= 4 && len(dst) >= 4 {
+		dst[0] = x[0] * factor
+		dst[1] = x[1] * factor
+		dst[2] = x[2] * factor
+		dst[3] = x[3] * factor
+		dst, x = dst[4:], x[4:]
+	}
+	for i := range x {
+		dst[i] = x[i] * factor
+	}
+}
+
+func addKernel[E kernelNumber](dst, a, b []E) {
+	a, b = a[:len(dst)], b[:len(dst)]
+	for len(dst) >= 4 && len(a) >= 4 && len(b) >= 4 {
+		dst[0] = a[0] + b[0]
+		dst[1] = a[1] + b[1]
+		dst[2] = a[2] + b[2]
+		dst[3] = a[3] + b[3]
+		dst, a, b = dst[4:], a[4:], b[4:]
+	}
+	for i := range dst {
+		dst[i] = a[i] + b[i]
+	}
+}
+
+// minKernel and maxKernel use the built-in min and max, which propagate NaNs like slices.Min
+// and slices.Max. x must not be empty.
+func minKernel[E kernelNumber](x []E) E {
+	m0, m1, m2, m3 := x[0], x[0], x[0], x[0]
+	for len(x) >= 4 {
+		m0 = min(m0, x[0])
+		m1 = min(m1, x[1])
+		m2 = min(m2, x[2])
+		m3 = min(m3, x[3])
+		x = x[4:]
+	}
+	for _, v := range x {
+		m0 = min(m0, v)
+	}
+	return min(m0, m1, m2, m3)
+}
+
+func maxKernel[E kernelNumber](x []E) E {
+	m0, m1, m2, m3 := x[0], x[0], x[0], x[0]
+	for len(x) >= 4 {
+		m0 = max(m0, x[0])
+		m1 = max(m1, x[1])
+		m2 = max(m2, x[2])
+		m3 = max(m3, x[3])
+		x = x[4:]
+	}
+	for _, v := range x {
+		m0 = max(m0, v)
+	}
+	return max(m0, m1, m2, m3)
+}
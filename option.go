@@ -0,0 +1,330 @@
+package goassist
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// Option holds either a value or nothing. It gives the helpers that can fail to produce a value,
+// such as FindOpt and MinOpt, a single convention instead of a mix of (T, bool) results and panics.
+//
+// The zero value holds nothing.
+//
+// Example:
+//
+//	admin := FindOpt(users, func(u User) bool { return u.Admin })
+//	greeting := MapOption(admin, func(u User) string { return "Hello, " + u.Name }).UnwrapOr("Hello")
+type Option[T any] struct {
+	value T
+	ok    bool
+}
+
+// OptionOf returns an Option holding v.
+func OptionOf[T any](v T) Option[T] {
+	return Option[T]{value: v, ok: true}
+}
+
+// None returns an Option holding nothing.
+func None[T any]() Option[T] {
+	return Option[T]{}
+}
+
+// OptionFrom converts a (value, found) pair, such as the result of Find, into an Option.
+//
+// Example:
+//
+//	first := OptionFrom(Find(numbers, func(x int) bool { return x > 3 }))
+func OptionFrom[T any](v T, ok bool) Option[T] {
+	if !ok {
+		return None[T]()
+	}
+	return OptionOf(v)
+}
+
+// IsSome reports whether the Option holds a value.
+func (o Option[T]) IsSome() bool {
+	return o.ok
+}
+
+// IsNone reports whether the Option holds nothing.
+func (o Option[T]) IsNone() bool {
+	return !o.ok
+}
+
+// Get returns the value and a boolean indicating if there is one.
+func (o Option[T]) Get() (T, bool) {
+	return o.value, o.ok
+}
+
+// Unwrap returns the value. It panics if the Option holds nothing.
+func (o Option[T]) Unwrap() T {
+	if !o.ok {
+		panic("goassist.Option: Unwrap of None")
+	}
+	return o.value
+}
+
+// UnwrapOr returns the value, or fallback if the Option holds nothing.
+func (o Option[T]) UnwrapOr(fallback T) T {
+	if !o.ok {
+		return fallback
+	}
+	return o.value
+}
+
+// UnwrapOrElse returns the value, or the result of fn if the Option holds nothing.
+func (o Option[T]) UnwrapOrElse(fn func() T) T {
+	if !o.ok {
+		return fn()
+	}
+	return o.value
+}
+
+// OrElse returns the Option itself if it holds a value, and the result of fn otherwise.
+//
+// Example:
+//
+//	user := FindOpt(cache, byID).OrElse(func() Option[User] {
+//		return FindOpt(database, byID)
+//	})
+func (o Option[T]) OrElse(fn func() Option[T]) Option[T] {
+	if !o.ok {
+		return fn()
+	}
+	return o
+}
+
+// String returns "Some(v)" or "None".
+func (o Option[T]) String() string {
+	if !o.ok {
+		return "None"
+	}
+	return fmt.Sprintf("Some(%v)", o.value)
+}
+
+// MapOption applies a function to the value of the Option, if there is one.
+//
+// Example:
+//
+//	length := MapOption(OptionOf("hello"), func(s string) int { return len(s) })
+//	// length is Some(5)
+func MapOption[T any, R any](o Option[T], fn func(T) R) Option[R] {
+	if !o.ok {
+		return None[R]()
+	}
+	return OptionOf(fn(o.value))
+}
+
+// AndThenOption applies a function that may itself produce nothing to the value of the Option,
+// if there is one.
+//
+// Example:
+//
+//	manager := AndThenOption(FindOpt(employees, byName), func(e Employee) Option[Employee] {
+//		return FindOpt(employees, func(m Employee) bool { return m.ID == e.ManagerID })
+//	})
+func AndThenOption[T any, R any](o Option[T], fn func(T) Option[R]) Option[R] {
+	if !o.ok {
+		return None[R]()
+	}
+	return fn(o.value)
+}
+
+// Result holds either a value or an error.
+//
+// Example:
+//
+//	port := MapResult(ResultOf(strconv.Atoi(s)), func(n int) uint16 { return uint16(n) })
+//	// port holds the parsed port, or the error returned by Atoi
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok returns a Result holding v.
+func Ok[T any](v T) Result[T] {
+	return Result[T]{value: v}
+}
+
+// Err returns a Result holding err. It panics if err is nil.
+func Err[T any](err error) Result[T] {
+	if err == nil {
+		panic("goassist.Err: nil error")
+	}
+	return Result[T]{err: err}
+}
+
+// ResultOf converts a (value, error) pair into a Result.
+//
+// Example:
+//
+//	n := ResultOf(strconv.Atoi("42"))
+//	// n is Ok(42)
+func ResultOf[T any](v T, err error) Result[T] {
+	if err != nil {
+		return Result[T]{err: err}
+	}
+	return Ok(v)
+}
+
+// IsOk reports whether the Result holds a value.
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// IsErr reports whether the Result holds an error.
+func (r Result[T]) IsErr() bool {
+	return r.err != nil
+}
+
+// Get returns the value and the error, in the usual Go form.
+func (r Result[T]) Get() (T, error) {
+	return r.value, r.err
+}
+
+// Err returns the error, or nil if the Result holds a value.
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// Unwrap returns the value. It panics with the error if the Result holds one.
+func (r Result[T]) Unwrap() T {
+	if r.err != nil {
+		panic(r.err)
+	}
+	return r.value
+}
+
+// UnwrapOr returns the value, or fallback if the Result holds an error.
+func (r Result[T]) UnwrapOr(fallback T) T {
+	if r.err != nil {
+		return fallback
+	}
+	return r.value
+}
+
+// UnwrapOrElse returns the value, or the result of fn applied to the error.
+func (r Result[T]) UnwrapOrElse(fn func(error) T) T {
+	if r.err != nil {
+		return fn(r.err)
+	}
+	return r.value
+}
+
+// OrElse returns the Result itself if it holds a value, and the result of fn applied to the error otherwise.
+//
+// Example:
+//
+//	config := ResultOf(loadFile(path)).OrElse(func(err error) Result[Config] {
+//		return ResultOf(loadDefaults())
+//	})
+func (r Result[T]) OrElse(fn func(error) Result[T]) Result[T] {
+	if r.err != nil {
+		return fn(r.err)
+	}
+	return r
+}
+
+// Option converts the Result into an Option, dropping the error.
+func (r Result[T]) Option() Option[T] {
+	return OptionFrom(r.value, r.err == nil)
+}
+
+// String returns "Ok(v)" or "Err(message)".
+func (r Result[T]) String() string {
+	if r.err != nil {
+		return fmt.Sprintf("Err(%v)", r.err)
+	}
+	return fmt.Sprintf("Ok(%v)", r.value)
+}
+
+// MapResult applies a function to the value of the Result, if there is one.
+func MapResult[T any, R any](r Result[T], fn func(T) R) Result[R] {
+	if r.err != nil {
+		return Result[R]{err: r.err}
+	}
+	return Ok(fn(r.value))
+}
+
+// AndThenResult applies a function that may itself fail to the value of the Result, if there is one.
+//
+// Example:
+//
+//	user := AndThenResult(ResultOf(strconv.Atoi(idParam)), func(id int) Result[User] {
+//		return ResultOf(store.Load(id))
+//	})
+func AndThenResult[T any, R any](r Result[T], fn func(T) Result[R]) Result[R] {
+	if r.err != nil {
+		return Result[R]{err: r.err}
+	}
+	return fn(r.value)
+}
+
+// FindOpt is like Find but returns an Option.
+//
+// Example:
+//
+//	numbers := []int{1, 2, 3, 4, 5}
+//	first := FindOpt(numbers, func(x int) bool { return x > 3 })
+//	// first is Some(4)
+func FindOpt[T any](arr []T, fn func(T) bool) Option[T] {
+	return OptionFrom(Find(arr, fn))
+}
+
+// MinOpt returns the minimum element in x, or None if x is empty.
+//
+// Example:
+//
+//	lowest := MinOpt([]int{})
+//	// lowest is None
+func MinOpt[S ~[]E, E cmp.Ordered](x S) Option[E] {
+	if len(x) == 0 {
+		return None[E]()
+	}
+	return OptionOf(Min(x))
+}
+
+// MaxOpt returns the maximum element in x, or None if x is empty.
+//
+// Example:
+//
+//	highest := MaxOpt([]int{4, 2, 5})
+//	// highest is Some(5)
+func MaxOpt[S ~[]E, E cmp.Ordered](x S) Option[E] {
+	if len(x) == 0 {
+		return None[E]()
+	}
+	return OptionOf(Max(x))
+}
+
+// FirstOpt returns the first element of the slice, or None if it is empty.
+func FirstOpt[S ~[]E, E any](s S) Option[E] {
+	return At(s, 0)
+}
+
+// LastOpt returns the last element of the slice, or None if it is empty.
+func LastOpt[S ~[]E, E any](s S) Option[E] {
+	return At(s, -1)
+}
+
+// At returns the element at index i, or None if i is out of range. Negative indices count
+// from the end of the slice, as in Python: At(s, -1) is the last element.
+//
+// Example:
+//
+//	names := []string{"alice", "bob", "carol"}
+//	second := At(names, 1)
+//	// second is Some(bob)
+//	last := At(names, -1)
+//	// last is Some(carol)
+//	fifth := At(names, 4)
+//	// fifth is None
+func At[S ~[]E, E any](s S, i int) Option[E] {
+	if i < 0 {
+		i += len(s)
+	}
+	if i < 0 || i >= len(s) {
+		return None[E]()
+	}
+	return OptionOf(s[i])
+}
@@ -0,0 +1,444 @@
+package goassist
+
+import (
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"math"
+	"math/bits"
+	"reflect"
+)
+
+// PersistentMap is an immutable map with structural sharing: Set and Delete return a new map and
+// leave the original unchanged, while the two share almost all of their memory. It can be passed
+// between goroutines without copying or locking.
+//
+// It is a hash array mapped trie, so Get, Set and Delete run in O(log32 n). Any comparable key
+// works: structs, arrays and interfaces are hashed field by field, following the same rules as ==,
+// so for example -0 and +0 are the same key. Iteration order is unspecified, as with built-in
+// maps. Use a PersistentMapBuilder to build large maps.
+//
+// The zero value is an empty map ready to use.
+//
+// Example:
+//
+//	m1 := PersistentMap[string, int]{}.Set("a", 1)
+//	m2 := m1.Set("b", 2)
+//	m3 := m2.Delete("a")
+//	// m1 is {a: 1}, m2 is {a: 1, b: 2}, m3 is {b: 2}
+type PersistentMap[K comparable, V any] struct {
+	count int
+	root  *hamtNode[K, V]
+}
+
+// hamtNode holds up to 32 slots, one per 5-bit chunk of the hash at its level. Only used slots are
+// stored: bitmap marks them and entries holds them in order. Keys whose whole hash is equal end up
+// in a collision node, whose entries are searched linearly.
+type hamtNode[K comparable, V any] struct {
+	edit      *editToken
+	bitmap    uint32
+	collision bool
+	entries   []hamtEntry[K, V]
+}
+
+// hamtEntry is either a key and its value, or a child node.
+type hamtEntry[K comparable, V any] struct {
+	hash  uint64
+	key   K
+	value V
+	child *hamtNode[K, V]
+}
+
+const hamtBits = 5
+
+// PersistentMapOf creates a PersistentMap holding the entries of a built-in map.
+func PersistentMapOf[K comparable, V any](m map[K]V) PersistentMap[K, V] {
+	var b PersistentMapBuilder[K, V]
+	for k, v := range m {
+		b.Set(k, v)
+	}
+	return b.Persistent()
+}
+
+// Len returns the number of entries in the map.
+func (m PersistentMap[K, V]) Len() int {
+	return m.count
+}
+
+// Get returns the value stored under key and a boolean indicating if it was found.
+func (m PersistentMap[K, V]) Get(key K) (V, bool) {
+	return hamtGet(m.root, key)
+}
+
+// Set returns a copy of the map with v stored under key.
+func (m PersistentMap[K, V]) Set(key K, v V) PersistentMap[K, V] {
+	var added bool
+	m.root, added = hamtSet(nil, m.root, hamtHash(key), 0, key, v)
+	if added {
+		m.count++
+	}
+	return m
+}
+
+// Delete returns a copy of the map without key. If key is not present, the map itself is returned.
+func (m PersistentMap[K, V]) Delete(key K) PersistentMap[K, V] {
+	root, removed := hamtDelete(nil, m.root, hamtHash(key), 0, key)
+	if removed {
+		m.root = root
+		m.count--
+	}
+	return m
+}
+
+// All returns an iterator over the keys and values of the map, in unspecified order.
+func (m PersistentMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.root.walk(yield)
+	}
+}
+
+// ToMap returns the entries of the map as a new built-in map.
+func (m PersistentMap[K, V]) ToMap() map[K]V {
+	result := make(map[K]V, m.count)
+	for k, v := range m.All() {
+		result[k] = v
+	}
+	return result
+}
+
+// String formats the map like a built-in map.
+func (m PersistentMap[K, V]) String() string {
+	return fmt.Sprint(m.ToMap())
+}
+
+// Builder returns a PersistentMapBuilder starting with the entries of the map.
+// The map itself is not modified.
+func (m PersistentMap[K, V]) Builder() *PersistentMapBuilder[K, V] {
+	return &PersistentMapBuilder[K, V]{count: m.count, root: m.root}
+}
+
+// PersistentMapBuilder builds a PersistentMap by changing its own nodes in place instead of
+// copying them. A builder must not be used from several goroutines at once; the maps it returns can be.
+//
+// The zero value is an empty builder ready to use.
+//
+// Example:
+//
+//	var b PersistentMapBuilder[int, string]
+//	for _, u := range users {
+//		b.Set(u.ID, u.Name)
+//	}
+//	names := b.Persistent()
+type PersistentMapBuilder[K comparable, V any] struct {
+	count int
+	root  *hamtNode[K, V]
+	edit  *editToken
+}
+
+// Len returns the number of entries in the builder.
+func (b *PersistentMapBuilder[K, V]) Len() int {
+	return b.count
+}
+
+// Get returns the value stored under key and a boolean indicating if it was found.
+func (b *PersistentMapBuilder[K, V]) Get(key K) (V, bool) {
+	return hamtGet(b.root, key)
+}
+
+// Set stores v under key, replacing any previous value.
+func (b *PersistentMapBuilder[K, V]) Set(key K, v V) {
+	var added bool
+	b.root, added = hamtSet(b.token(), b.root, hamtHash(key), 0, key, v)
+	if added {
+		b.count++
+	}
+}
+
+// Delete removes key and reports whether it was present.
+func (b *PersistentMapBuilder[K, V]) Delete(key K) bool {
+	root, removed := hamtDelete(b.token(), b.root, hamtHash(key), 0, key)
+	if removed {
+		b.root = root
+		b.count--
+	}
+	return removed
+}
+
+// Persistent returns a PersistentMap holding the current entries. The builder can still be
+// used afterwards; later changes do not affect the returned map.
+func (b *PersistentMapBuilder[K, V]) Persistent() PersistentMap[K, V] {
+	// Give up ownership of the current nodes, so that later changes copy them.
+	b.edit = nil
+	return PersistentMap[K, V]{count: b.count, root: b.root}
+}
+
+func (b *PersistentMapBuilder[K, V]) token() *editToken {
+	if b.edit == nil {
+		b.edit = &editToken{}
+	}
+	return b.edit
+}
+
+// slot returns the bit of the slot for hash at shift and the position of that slot in entries.
+func (n *hamtNode[K, V]) slot(hash uint64, shift uint) (uint32, int) {
+	bit := hamtBit(hash, shift)
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// editable returns n if it belongs to edit, and a copy belonging to edit otherwise.
+func (n *hamtNode[K, V]) editable(edit *editToken) *hamtNode[K, V] {
+	if edit != nil && n.edit == edit {
+		return n
+	}
+	c := *n
+	c.edit = edit
+	c.entries = Clone(n.entries)
+	return &c
+}
+
+func (n *hamtNode[K, V]) walk(yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	for _, e := range n.entries {
+		if e.child != nil {
+			if !e.child.walk(yield) {
+				return false
+			}
+		} else if !yield(e.key, e.value) {
+			return false
+		}
+	}
+	return true
+}
+
+func hamtGet[K comparable, V any](n *hamtNode[K, V], key K) (V, bool) {
+	var zero V
+	if n == nil {
+		return zero, false
+	}
+	hash := hamtHash(key)
+	for shift := uint(0); ; shift += hamtBits {
+		if n.collision {
+			for _, e := range n.entries {
+				if e.key == key {
+					return e.value, true
+				}
+			}
+			return zero, false
+		}
+
+		bit, i := n.slot(hash, shift)
+		if n.bitmap&bit == 0 {
+			return zero, false
+		}
+		e := n.entries[i]
+		if e.child == nil {
+			if e.hash == hash && e.key == key {
+				return e.value, true
+			}
+			return zero, false
+		}
+		n = e.child
+	}
+}
+
+// hamtSet returns the node with v stored under key and reports whether the key is new.
+func hamtSet[K comparable, V any](edit *editToken, n *hamtNode[K, V], hash uint64, shift uint, key K, v V) (*hamtNode[K, V], bool) {
+	leaf := hamtEntry[K, V]{hash: hash, key: key, value: v}
+	if n == nil {
+		return &hamtNode[K, V]{edit: edit, bitmap: hamtBit(hash, shift), entries: []hamtEntry[K, V]{leaf}}, true
+	}
+
+	if n.collision {
+		i := IndexFunc(n.entries, func(e hamtEntry[K, V]) bool { return e.key == key })
+		n = n.editable(edit)
+		if i >= 0 {
+			n.entries[i].value = v
+			return n, false
+		}
+		n.entries = append(n.entries, leaf)
+		return n, true
+	}
+
+	bit, i := n.slot(hash, shift)
+	if n.bitmap&bit == 0 {
+		n = n.editable(edit)
+		n.bitmap |= bit
+		n.entries = Insert(n.entries, i, leaf)
+		return n, true
+	}
+
+	e := n.entries[i]
+	var added bool
+	switch {
+	case e.child != nil:
+		e.child, added = hamtSet(edit, e.child, hash, shift+hamtBits, key, v)
+	case e.hash == hash && e.key == key:
+		e.value = v
+	default:
+		e = hamtEntry[K, V]{child: hamtMerge(edit, e, leaf, shift+hamtBits)}
+		added = true
+	}
+	n = n.editable(edit)
+	n.entries[i] = e
+	return n, added
+}
+
+// hamtMerge returns a node holding two entries whose hashes agree up to shift.
+func hamtMerge[K comparable, V any](edit *editToken, a, b hamtEntry[K, V], shift uint) *hamtNode[K, V] {
+	if shift >= 64 {
+		return &hamtNode[K, V]{edit: edit, collision: true, entries: []hamtEntry[K, V]{a, b}}
+	}
+	bitA, bitB := hamtBit(a.hash, shift), hamtBit(b.hash, shift)
+	switch {
+	case bitA == bitB:
+		child := hamtMerge(edit, a, b, shift+hamtBits)
+		return &hamtNode[K, V]{edit: edit, bitmap: bitA, entries: []hamtEntry[K, V]{{child: child}}}
+	case bitA < bitB:
+		return &hamtNode[K, V]{edit: edit, bitmap: bitA | bitB, entries: []hamtEntry[K, V]{a, b}}
+	default:
+		return &hamtNode[K, V]{edit: edit, bitmap: bitA | bitB, entries: []hamtEntry[K, V]{b, a}}
+	}
+}
+
+// hamtDelete returns the node without key and reports whether the key was present.
+// The returned node is nil once it holds no entries.
+func hamtDelete[K comparable, V any](edit *editToken, n *hamtNode[K, V], hash uint64, shift uint, key K) (*hamtNode[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	if n.collision {
+		i := IndexFunc(n.entries, func(e hamtEntry[K, V]) bool { return e.key == key })
+		if i < 0 {
+			return n, false
+		}
+		n = n.editable(edit)
+		n.entries = Delete(n.entries, i, i+1)
+		return n, true
+	}
+
+	bit, i := n.slot(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	e := n.entries[i]
+	if e.child == nil {
+		if e.hash != hash || e.key != key {
+			return n, false
+		}
+		if len(n.entries) == 1 {
+			return nil, true
+		}
+		n = n.editable(edit)
+		n.bitmap &^= bit
+		n.entries = Delete(n.entries, i, i+1)
+		return n, true
+	}
+
+	child, removed := hamtDelete(edit, e.child, hash, shift+hamtBits, key)
+	if !removed {
+		return n, false
+	}
+	n = n.editable(edit)
+	switch {
+	case child == nil:
+		if len(n.entries) == 1 {
+			return nil, true
+		}
+		n.bitmap &^= bit
+		n.entries = Delete(n.entries, i, i+1)
+	case len(child.entries) == 1 && child.entries[0].child == nil:
+		// A lone key moves up, so that the trie stays as shallow as possible.
+		n.entries[i] = child.entries[0]
+	default:
+		n.entries[i] = hamtEntry[K, V]{child: child}
+	}
+	return n, true
+}
+
+func hamtBit(hash uint64, shift uint) uint32 {
+	return uint32(1) << ((hash >> shift) & (1<<hamtBits - 1))
+}
+
+var hamtSeed = maphash.MakeSeed()
+
+// hamtHash hashes any comparable key so that keys equal under == get equal hashes. Common key
+// types take a fast path; other keys are walked with reflect.
+func hamtHash[K comparable](key K) uint64 {
+	switch k := any(key).(type) {
+	case string:
+		return maphash.String(hamtSeed, k)
+	case int:
+		return hamtMix(uint64(k))
+	case int64:
+		return hamtMix(uint64(k))
+	case uint64:
+		return hamtMix(k)
+	}
+	return hamtHashValue(reflect.ValueOf(key))
+}
+
+// hamtHashValue hashes v by kind, combining the hashes of struct fields, array elements and
+// interface values in order. It panics for values that cannot be compared, like built-in maps do.
+func hamtHashValue(v reflect.Value) uint64 {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return 0
+	case reflect.String:
+		return maphash.String(hamtSeed, v.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return hamtMix(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return hamtMix(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return hamtHashFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		return hamtMix(hamtMix(hamtHashFloat(real(c))) ^ hamtHashFloat(imag(c)))
+	case reflect.Bool:
+		if v.Bool() {
+			return hamtMix(1)
+		}
+		return hamtMix(0)
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return hamtMix(uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		return hamtHashValue(v.Elem())
+	case reflect.Struct:
+		h := hamtMix(uint64(v.NumField()))
+		for i := range v.NumField() {
+			h = hamtMix(h ^ hamtHashValue(v.Field(i)))
+		}
+		return h
+	case reflect.Array:
+		h := hamtMix(uint64(v.Len()))
+		for i := range v.Len() {
+			h = hamtMix(h ^ hamtHashValue(v.Index(i)))
+		}
+		return h
+	default:
+		panic("goassist.PersistentMap: hash of unhashable type " + v.Type().String())
+	}
+}
+
+func hamtHashFloat(f float64) uint64 {
+	if f == 0 {
+		f = 0 // -0 and +0 are equal keys.
+	}
+	return hamtMix(math.Float64bits(f))
+}
+
+var hamtIntSeed = maphash.String(hamtSeed, "goassist")
+
+// hamtMix spreads the bits of x over the whole hash (the splitmix64 finalizer).
+func hamtMix(x uint64) uint64 {
+	x ^= hamtIntSeed
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
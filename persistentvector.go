@@ -0,0 +1,306 @@
+package goassist
+
+import (
+	"fmt"
+	"iter"
+)
+
+const (
+	pvBits  = 5
+	pvWidth = 1 << pvBits
+	pvMask  = pvWidth - 1
+)
+
+// editToken marks the nodes a builder may change in place. Nodes created by a builder carry its
+// token; every other node is shared and copied before it is changed. The field keeps the size
+// non-zero, so that every token has a distinct address.
+type editToken struct {
+	_ byte
+}
+
+// PersistentVector is an immutable sequence with structural sharing: Set and Append return a new
+// vector and leave the original unchanged, while the two share almost all of their memory.
+// It can be passed between goroutines without copying or locking.
+//
+// Elements are stored in a 32-way trie, so Get, Set and Append run in O(log32 n), which is at most
+// 7 steps for any vector that fits in memory. Use a PersistentVectorBuilder to build large vectors.
+//
+// The zero value is an empty vector ready to use.
+//
+// Example:
+//
+//	v1 := PersistentVectorOf([]string{"a", "b"})
+//	v2 := v1.Append("c")
+//	v3 := v2.Set(0, "z")
+//	// v1 is [a b], v2 is [a b c], v3 is [z b c]
+type PersistentVector[T any] struct {
+	count int
+	shift uint
+	root  *pvNode[T]
+	tail  []T
+}
+
+type pvNode[T any] struct {
+	edit     *editToken
+	children []*pvNode[T]
+	values   []T
+}
+
+// PersistentVectorOf creates a PersistentVector holding the elements of the slice.
+func PersistentVectorOf[T any](s []T) PersistentVector[T] {
+	var b PersistentVectorBuilder[T]
+	b.Append(s...)
+	return b.Persistent()
+}
+
+// Len returns the number of elements in the vector.
+func (v PersistentVector[T]) Len() int {
+	return v.count
+}
+
+// Get returns the element at index i. It panics if i is out of range.
+func (v PersistentVector[T]) Get(i int) T {
+	if i < 0 || i >= v.count {
+		panic(fmt.Sprintf("goassist.PersistentVector: index %d out of range with length %d", i, v.count))
+	}
+	return pvLeaf(v.root, v.shift, v.count, v.tail, i)[i&pvMask]
+}
+
+// Set returns a copy of the vector with the element at index i replaced by x.
+// It panics if i is out of range.
+func (v PersistentVector[T]) Set(i int, x T) PersistentVector[T] {
+	if i < 0 || i >= v.count {
+		panic(fmt.Sprintf("goassist.PersistentVector: index %d out of range with length %d", i, v.count))
+	}
+	if i >= pvTailOffset(v.count) {
+		tail := Clone(v.tail)
+		tail[i&pvMask] = x
+		v.tail = tail
+		return v
+	}
+	v.root = pvAssoc(nil, v.root, v.shift, i, x)
+	return v
+}
+
+// Append returns a copy of the vector with the values added at the end.
+//
+// Example:
+//
+//	empty := PersistentVector[int]{}
+//	numbers := empty.Append(1, 2, 3)
+//	// empty.Len() is 0, numbers.Len() is 3
+func (v PersistentVector[T]) Append(values ...T) PersistentVector[T] {
+	if len(values) > 1 {
+		b := v.Builder()
+		b.Append(values...)
+		return b.Persistent()
+	}
+	for _, x := range values {
+		if len(v.tail) < pvWidth {
+			tail := make([]T, len(v.tail)+1)
+			copy(tail, v.tail)
+			tail[len(v.tail)] = x
+			v.tail = tail
+		} else {
+			v.root, v.shift = pvPushTail(nil, v.root, v.shift, v.count, v.tail)
+			v.tail = []T{x}
+		}
+		v.count++
+	}
+	return v
+}
+
+// All returns an iterator over the indexes and elements of the vector, in order.
+func (v PersistentVector[T]) All() iter.Seq2[int, T] {
+	return pvAll(v.root, v.shift, v.count, v.tail)
+}
+
+// ToSlice returns the elements of the vector as a new slice.
+func (v PersistentVector[T]) ToSlice() []T {
+	result := make([]T, 0, v.count)
+	for _, x := range v.All() {
+		result = append(result, x)
+	}
+	return result
+}
+
+// String formats the vector like a slice.
+func (v PersistentVector[T]) String() string {
+	return fmt.Sprint(v.ToSlice())
+}
+
+// Builder returns a PersistentVectorBuilder starting with the elements of the vector.
+// The vector itself is not modified.
+func (v PersistentVector[T]) Builder() *PersistentVectorBuilder[T] {
+	tail := make([]T, len(v.tail), pvWidth)
+	copy(tail, v.tail)
+	return &PersistentVectorBuilder[T]{count: v.count, shift: v.shift, root: v.root, tail: tail}
+}
+
+// PersistentVectorBuilder builds a PersistentVector by changing its own nodes in place instead of
+// copying them, which makes bulk construction about as fast as appending to a slice.
+// A builder must not be used from several goroutines at once; the vectors it returns can be.
+//
+// The zero value is an empty builder ready to use.
+//
+// Example:
+//
+//	var b PersistentVectorBuilder[int]
+//	for i := range 100000 {
+//		b.Append(i)
+//	}
+//	v := b.Persistent()
+//	// v.Len() is 100000
+type PersistentVectorBuilder[T any] struct {
+	count int
+	shift uint
+	root  *pvNode[T]
+	tail  []T
+	edit  *editToken
+}
+
+// Len returns the number of elements in the builder.
+func (b *PersistentVectorBuilder[T]) Len() int {
+	return b.count
+}
+
+// Get returns the element at index i. It panics if i is out of range.
+func (b *PersistentVectorBuilder[T]) Get(i int) T {
+	if i < 0 || i >= b.count {
+		panic(fmt.Sprintf("goassist.PersistentVectorBuilder: index %d out of range with length %d", i, b.count))
+	}
+	return pvLeaf(b.root, b.shift, b.count, b.tail, i)[i&pvMask]
+}
+
+// Set replaces the element at index i with x. It panics if i is out of range.
+func (b *PersistentVectorBuilder[T]) Set(i int, x T) {
+	if i < 0 || i >= b.count {
+		panic(fmt.Sprintf("goassist.PersistentVectorBuilder: index %d out of range with length %d", i, b.count))
+	}
+	if i >= pvTailOffset(b.count) {
+		b.tail[i&pvMask] = x
+		return
+	}
+	b.root = pvAssoc(b.token(), b.root, b.shift, i, x)
+}
+
+// Append adds the values at the end.
+func (b *PersistentVectorBuilder[T]) Append(values ...T) {
+	for _, x := range values {
+		if b.tail == nil {
+			b.tail = make([]T, 0, pvWidth)
+		}
+		if len(b.tail) == pvWidth {
+			b.root, b.shift = pvPushTail(b.token(), b.root, b.shift, b.count, b.tail)
+			b.tail = make([]T, 0, pvWidth)
+		}
+		b.tail = append(b.tail, x)
+		b.count++
+	}
+}
+
+// Persistent returns a PersistentVector holding the current elements. The builder can still be
+// used afterwards; later changes do not affect the returned vector.
+func (b *PersistentVectorBuilder[T]) Persistent() PersistentVector[T] {
+	// Give up ownership of the current nodes, so that later changes copy them.
+	b.edit = nil
+	return PersistentVector[T]{count: b.count, shift: b.shift, root: b.root, tail: Clone(b.tail)}
+}
+
+func (b *PersistentVectorBuilder[T]) token() *editToken {
+	if b.edit == nil {
+		b.edit = &editToken{}
+	}
+	return b.edit
+}
+
+// pvTailOffset returns the index of the first element stored in the tail.
+func pvTailOffset(count int) int {
+	if count < pvWidth {
+		return 0
+	}
+	return (count - 1) &^ pvMask
+}
+
+// pvLeaf returns the 32-element block holding index i.
+func pvLeaf[T any](root *pvNode[T], shift uint, count int, tail []T, i int) []T {
+	if i >= pvTailOffset(count) {
+		return tail
+	}
+	n := root
+	for level := shift; level > 0; level -= pvBits {
+		n = n.children[(i>>level)&pvMask]
+	}
+	return n.values
+}
+
+// editable returns n if it belongs to edit, and a copy belonging to edit otherwise.
+func (n *pvNode[T]) editable(edit *editToken) *pvNode[T] {
+	if edit != nil && n.edit == edit {
+		return n
+	}
+	return &pvNode[T]{edit: edit, children: Clone(n.children), values: Clone(n.values)}
+}
+
+func pvAssoc[T any](edit *editToken, n *pvNode[T], level uint, i int, x T) *pvNode[T] {
+	n = n.editable(edit)
+	if level == 0 {
+		n.values[i&pvMask] = x
+		return n
+	}
+	sub := (i >> level) & pvMask
+	n.children[sub] = pvAssoc(edit, n.children[sub], level-pvBits, i, x)
+	return n
+}
+
+// pvPushTail moves a full tail into the trie, growing it by a level when the root is full.
+// count is the number of elements including the tail.
+func pvPushTail[T any](edit *editToken, root *pvNode[T], shift uint, count int, tail []T) (*pvNode[T], uint) {
+	leaf := &pvNode[T]{edit: edit, values: tail}
+	if root == nil {
+		root, shift = &pvNode[T]{edit: edit, children: make([]*pvNode[T], pvWidth)}, pvBits
+	}
+	if count>>pvBits > 1<<shift {
+		grown := &pvNode[T]{edit: edit, children: make([]*pvNode[T], pvWidth)}
+		grown.children[0] = root
+		grown.children[1] = pvNewPath(edit, shift, leaf)
+		return grown, shift + pvBits
+	}
+	return pvPushLeaf(edit, root, shift, count, leaf), shift
+}
+
+func pvPushLeaf[T any](edit *editToken, n *pvNode[T], level uint, count int, leaf *pvNode[T]) *pvNode[T] {
+	n = n.editable(edit)
+	sub := ((count - 1) >> level) & pvMask
+	switch {
+	case level == pvBits:
+		n.children[sub] = leaf
+	case n.children[sub] != nil:
+		n.children[sub] = pvPushLeaf(edit, n.children[sub], level-pvBits, count, leaf)
+	default:
+		n.children[sub] = pvNewPath(edit, level-pvBits, leaf)
+	}
+	return n
+}
+
+func pvNewPath[T any](edit *editToken, level uint, leaf *pvNode[T]) *pvNode[T] {
+	if level == 0 {
+		return leaf
+	}
+	n := &pvNode[T]{edit: edit, children: make([]*pvNode[T], pvWidth)}
+	n.children[0] = pvNewPath(edit, level-pvBits, leaf)
+	return n
+}
+
+func pvAll[T any](root *pvNode[T], shift uint, count int, tail []T) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for start := 0; start < count; start += pvWidth {
+			block := pvLeaf(root, shift, count, tail, start)
+			for j, x := range block[:min(pvWidth, count-start)] {
+				if !yield(start+j, x) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,377 @@
+package goassist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// ErrItemTimeout is reported when an attempt exceeds Policy.Timeout or an item exceeds Policy.ItemTimeout.
+var ErrItemTimeout = errors.New("goassist: item timed out")
+
+// Clock abstracts the passage of time so that policies can be driven by a fake clock in tests.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// SystemClock is the Clock backed by the time package. It is used when Policy.Clock is nil.
+var SystemClock Clock = systemClock{}
+
+// Policy describes how ForEachWithPolicy and MapWithPolicy throttle, parallelize and retry work.
+// The zero value processes items one at a time, without rate limiting, retries or timeouts.
+//
+// Example:
+//
+//	policy := Policy{
+//		Rate:           50,                     // at most 50 attempts per second
+//		Burst:          10,                     // allow short bursts of 10
+//		MaxConcurrency: 8,                      // at most 8 items in flight
+//		MaxRetries:     3,                      // up to 4 attempts per item
+//		BaseDelay:      100 * time.Millisecond, // 100ms, 200ms, 400ms, ...
+//		MaxDelay:       2 * time.Second,
+//		Jitter:         0.2,
+//		Timeout:        5 * time.Second,  // per attempt
+//		ItemTimeout:    20 * time.Second, // per item, including retries and waits
+//	}
+type Policy struct {
+	// Rate is the number of attempts started per second. Zero disables rate limiting.
+	Rate float64
+	// Burst is the capacity of the token bucket. Values below 1 are treated as 1.
+	Burst int
+	// MaxConcurrency is the number of items processed at once. Values below 1 are treated as 1.
+	MaxConcurrency int
+	// MaxRetries is the number of additional attempts made after a failed one.
+	MaxRetries int
+	// BaseDelay is the backoff before the first retry; it doubles on every further retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff. Zero means no cap.
+	MaxDelay time.Duration
+	// Jitter is the fraction, between 0 and 1, of each backoff that is randomly subtracted.
+	Jitter float64
+	// Timeout bounds every single attempt. Zero means no timeout.
+	Timeout time.Duration
+	// ItemTimeout bounds the whole processing of an item: rate limiting, all attempts and the
+	// backoff between them. Zero means no timeout.
+	ItemTimeout time.Duration
+	// Retryable reports whether a failed attempt should be retried. Nil retries every error.
+	Retryable func(error) bool
+	// Clock is used for rate limiting, backoff and timeouts. Nil means SystemClock.
+	Clock Clock
+	// Rand is the source of jitter. Nil means the math/rand/v2 global source.
+	Rand *rand.Rand
+}
+
+// ItemError records the failure of the item at Index in ForEachWithPolicy and MapWithPolicy.
+type ItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("goassist: item %d: %v", e.Index, e.Err)
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// ForEachWithPolicy calls fn for every element of the slice according to the policy.
+// Failed items do not stop the others; their errors are returned joined as *ItemError values.
+// When ctx is canceled no further items are started and ctx.Err() is part of the returned error.
+//
+// Example:
+//
+//	ids := []int{1, 2, 3, 4, 5}
+//	err := ForEachWithPolicy(ctx, ids, Policy{Rate: 10, MaxConcurrency: 2, MaxRetries: 2},
+//		func(ctx context.Context, id int) error {
+//			return client.Touch(ctx, id)
+//		})
+//	// every id was touched at most 10 times per second, two at a time
+func ForEachWithPolicy[T any](ctx context.Context, arr []T, policy Policy, fn func(context.Context, T) error) error {
+	_, err := MapWithPolicy(ctx, arr, policy, func(ctx context.Context, v T) (struct{}, error) {
+		return struct{}{}, fn(ctx, v)
+	})
+	return err
+}
+
+// MapWithPolicy applies fn to every element of the slice according to the policy and returns
+// the results in input order. Results of failed items are left as zero values and their errors
+// are returned joined as *ItemError values.
+//
+// Example:
+//
+//	ids := []int{1, 2, 3}
+//	users, err := MapWithPolicy(ctx, ids, Policy{Rate: 5, MaxRetries: 3, BaseDelay: time.Second},
+//		func(ctx context.Context, id int) (User, error) {
+//			return client.GetUser(ctx, id)
+//		})
+//	// users[i] belongs to ids[i]
+func MapWithPolicy[T any, R any](ctx context.Context, arr []T, policy Policy, fn func(context.Context, T) (R, error)) ([]R, error) {
+	result := make([]R, len(arr))
+	errs := make([]error, len(arr))
+	runner := newPolicyRunner(policy)
+
+	workers := min(max(policy.MaxConcurrency, 1), len(arr))
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				if ctx.Err() != nil {
+					continue
+				}
+				result[i], errs[i] = runWithPolicy(ctx, runner, arr[i], fn)
+			}
+		}()
+	}
+
+feed:
+	for i := range arr {
+		// select picks at random when both cases are ready, so check ctx first to stop promptly.
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case indices <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indices)
+	wg.Wait()
+
+	failures := make([]error, 0)
+	if err := ctx.Err(); err != nil {
+		failures = append(failures, err)
+	}
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, &ItemError{Index: i, Err: err})
+		}
+	}
+
+	return result, errors.Join(failures...)
+}
+
+func runWithPolicy[T any, R any](ctx context.Context, runner *policyRunner, v T, fn func(context.Context, T) (R, error)) (R, error) {
+	if runner.policy.ItemTimeout <= 0 {
+		return retryWithPolicy(ctx, runner, v, fn)
+	}
+
+	itemCtx, stop := withClockTimeout(ctx, runner.clock, runner.policy.ItemTimeout)
+	defer stop()
+
+	result, err := retryWithPolicy(itemCtx, runner, v, fn)
+	if err != nil && !errors.Is(err, ErrItemTimeout) && errors.Is(context.Cause(itemCtx), ErrItemTimeout) {
+		err = fmt.Errorf("%w: %w", ErrItemTimeout, err)
+	}
+
+	return result, err
+}
+
+func retryWithPolicy[T any, R any](ctx context.Context, runner *policyRunner, v T, fn func(context.Context, T) (R, error)) (R, error) {
+	for attempt := 0; ; attempt++ {
+		if err := runner.limiter.wait(ctx); err != nil {
+			var zero R
+			return zero, err
+		}
+
+		result, err := attemptWithTimeout(ctx, runner, v, fn)
+		if err == nil {
+			return result, nil
+		}
+
+		if attempt >= runner.policy.MaxRetries || ctx.Err() != nil || !runner.retryable(err) {
+			return result, err
+		}
+
+		if sleepErr := sleep(ctx, runner.clock, runner.backoff(attempt)); sleepErr != nil {
+			return result, errors.Join(err, sleepErr)
+		}
+	}
+}
+
+func attemptWithTimeout[T any, R any](ctx context.Context, runner *policyRunner, v T, fn func(context.Context, T) (R, error)) (R, error) {
+	if runner.policy.Timeout <= 0 {
+		return fn(ctx, v)
+	}
+
+	attemptCtx, stop := withClockTimeout(ctx, runner.clock, runner.policy.Timeout)
+	defer stop()
+
+	result, err := fn(attemptCtx, v)
+	if err != nil && errors.Is(context.Cause(attemptCtx), ErrItemTimeout) {
+		err = fmt.Errorf("%w: %w", ErrItemTimeout, err)
+	}
+
+	return result, err
+}
+
+// withClockTimeout returns a context that is canceled with the cause ErrItemTimeout once d has
+// passed on the clock. The returned function releases the timer and must be called.
+func withClockTimeout(ctx context.Context, clock Clock, d time.Duration) (context.Context, func()) {
+	timeoutCtx, cancel := context.WithCancelCause(ctx)
+	done := make(chan struct{})
+
+	timer := clock.After(d)
+	go func() {
+		select {
+		case <-timer:
+			cancel(ErrItemTimeout)
+		case <-done:
+		}
+	}()
+
+	return timeoutCtx, func() {
+		close(done)
+		cancel(nil)
+	}
+}
+
+type policyRunner struct {
+	policy  Policy
+	clock   Clock
+	limiter *tokenBucket
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func newPolicyRunner(policy Policy) *policyRunner {
+	clock := policy.Clock
+	if clock == nil {
+		clock = SystemClock
+	}
+
+	return &policyRunner{
+		policy:  policy,
+		clock:   clock,
+		limiter: newTokenBucket(clock, policy.Rate, policy.Burst),
+		rand:    policy.Rand,
+	}
+}
+
+func (r *policyRunner) retryable(err error) bool {
+	if r.policy.Retryable == nil {
+		return true
+	}
+	return r.policy.Retryable(err)
+}
+
+func (r *policyRunner) backoff(attempt int) time.Duration {
+	// After 63 doublings any positive BaseDelay exceeds the longest Duration, so stop there
+	// rather than let the float grow to infinity.
+	delay := float64(r.policy.BaseDelay) * math.Pow(2, float64(min(attempt, 63)))
+	if r.policy.MaxDelay > 0 {
+		delay = min(delay, float64(r.policy.MaxDelay))
+	}
+
+	if jitter := min(max(r.policy.Jitter, 0), 1); jitter > 0 {
+		delay -= delay * jitter * r.float64()
+	}
+
+	// Converting a float at or above 2^63 to a Duration overflows to a negative value,
+	// which sleep would treat as no wait at all.
+	if delay >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(delay)
+}
+
+func (r *policyRunner) float64() float64 {
+	if r.rand == nil {
+		return rand.Float64()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rand.Float64()
+}
+
+// tokenBucket hands out tokens at a fixed rate. Callers reserve a token up front and
+// sleep until it becomes available, so waiters are served in arrival order.
+type tokenBucket struct {
+	mu     sync.Mutex
+	clock  Clock
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(clock Clock, rate float64, burst int) *tokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+
+	capacity := float64(max(burst, 1))
+	return &tokenBucket{
+		clock:  clock,
+		rate:   rate,
+		burst:  capacity,
+		tokens: capacity,
+		last:   clock.Now(),
+	}
+}
+
+func (b *tokenBucket) wait(ctx context.Context) error {
+	if err := ctx.Err(); b == nil || err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	b.refill()
+	b.tokens--
+	tokens := b.tokens
+	b.mu.Unlock()
+
+	if tokens >= 0 {
+		return ctx.Err()
+	}
+
+	err := sleep(ctx, b.clock, time.Duration(math.Ceil(-tokens/b.rate*float64(time.Second))))
+	if err != nil {
+		// The reserved token was never used; give it back so later callers are not delayed for it.
+		b.mu.Lock()
+		b.refill()
+		b.tokens = min(b.burst, b.tokens+1)
+		b.mu.Unlock()
+	}
+	return err
+}
+
+// refill adds the tokens earned since the last call. b.mu must be held.
+func (b *tokenBucket) refill() {
+	now := b.clock.Now()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+}
+
+func sleep(ctx context.Context, clock Clock, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	select {
+	case <-clock.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,55 @@
+package goassist
+
+import "sync"
+
+// SlicePool is a typed, sync.Pool-backed pool of slices for reusing buffers in hot paths,
+// for example as the dst argument of MapInto and FilterInto. It is safe for concurrent use.
+// Like sync.Pool, it may drop pooled slices at any time, so Get allocates when the pool is empty.
+//
+// Example:
+//
+//	pool := NewSlicePool[int](256)
+//	buf := pool.Get()
+//	buf = MapInto(buf, rows, func(r Row) int { return r.ID })
+//	process(buf)
+//	pool.Put(buf)
+type SlicePool[T any] struct {
+	capacity int
+	slices   sync.Pool
+	// boxes recycles the *[]T headers stored in slices, so that Put does not allocate
+	// a new one every time.
+	boxes sync.Pool
+}
+
+// NewSlicePool creates a SlicePool whose new slices have the given capacity.
+func NewSlicePool[T any](capacity int) *SlicePool[T] {
+	return &SlicePool[T]{capacity: max(capacity, 0)}
+}
+
+// Get returns an empty slice from the pool, or a new one with the pool's capacity if the pool is empty.
+func (p *SlicePool[T]) Get() []T {
+	box, ok := p.slices.Get().(*[]T)
+	if !ok {
+		return make([]T, 0, p.capacity)
+	}
+	s := *box
+	*box = nil
+	p.boxes.Put(box)
+	return s
+}
+
+// Put returns a slice to the pool. Its elements are zeroed first, so that the pool does not keep
+// the values they reference alive. The slice must not be used after Put.
+func (p *SlicePool[T]) Put(s []T) {
+	if cap(s) == 0 {
+		return
+	}
+	clear(s[:cap(s)])
+
+	box, ok := p.boxes.Get().(*[]T)
+	if !ok {
+		box = new([]T)
+	}
+	*box = s[:0]
+	p.slices.Put(box)
+}
@@ -0,0 +1,229 @@
+package goassist
+
+import (
+	"errors"
+	"iter"
+	"math"
+	"math/rand/v2"
+)
+
+// ErrInvalidWeights is returned when weights do not match the elements, are negative or not finite,
+// or add up to zero.
+var ErrInvalidWeights = errors.New("goassist: invalid weights")
+
+// Shuffle randomly permutes the slice in place using r.
+//
+// Example:
+//
+//	r := rand.New(rand.NewPCG(1, 2))
+//	deck := []int{1, 2, 3, 4, 5}
+//	Shuffle(deck, r)
+//	// deck holds the same elements in an order fixed by the seed
+func Shuffle[S ~[]E, E any](s S, r *rand.Rand) {
+	r.Shuffle(len(s), func(i, j int) {
+		s[i], s[j] = s[j], s[i]
+	})
+}
+
+// Sample returns k distinct elements of the slice chosen uniformly at random using r,
+// in random order. If k exceeds the length of the slice, all elements are returned.
+// The input slice is not modified and the cost is O(k).
+//
+// Example:
+//
+//	r := rand.New(rand.NewPCG(1, 2))
+//	users := []string{"alice", "bob", "carol", "dave"}
+//	winners := Sample(users, 2, r)
+//	// winners holds two different users
+func Sample[S ~[]E, E any](s S, k int, r *rand.Rand) S {
+	k = min(max(k, 0), len(s))
+	result := make(S, k)
+
+	// Partial Fisher-Yates over a virtual copy of the indices; swapped records the moved ones.
+	swapped := make(map[int]int, k)
+	at := func(i int) int {
+		if j, ok := swapped[i]; ok {
+			return j
+		}
+		return i
+	}
+	for i := range k {
+		j := i + r.IntN(len(s)-i)
+		result[i] = s[at(j)]
+		swapped[j] = at(i)
+	}
+	return result
+}
+
+// ReservoirSample returns k elements chosen uniformly at random from a sequence of unknown length
+// using r, reading it once. If the sequence holds fewer than k elements, all of them are returned.
+//
+// Example:
+//
+//	r := rand.New(rand.NewPCG(1, 2))
+//	sessions := map[string]int{"a": 1, "b": 2, "c": 3}
+//	ids := ReservoirSample(maps.Keys(sessions), 2, r)
+//	// ids holds two random session IDs
+func ReservoirSample[T any](seq iter.Seq[T], k int, r *rand.Rand) []T {
+	result := make([]T, 0, max(k, 0))
+	if k <= 0 {
+		return result
+	}
+
+	n := 0
+	for v := range seq {
+		n++
+		if len(result) < k {
+			result = append(result, v)
+			continue
+		}
+		if j := r.IntN(n); j < k {
+			result[j] = v
+		}
+	}
+	return result
+}
+
+// WeightedChoice returns one element of the slice chosen at random using r, with probability
+// proportional to its weight. It returns ErrInvalidWeights if the weights are unusable.
+//
+// Example:
+//
+//	r := rand.New(rand.NewPCG(1, 2))
+//	variants := []string{"control", "treatment"}
+//	variant, err := WeightedChoice(variants, []float64{0.9, 0.1}, r)
+//	// variant is "control" about 90% of the time
+func WeightedChoice[S ~[]E, E any](s S, weights []float64, r *rand.Rand) (E, error) {
+	var zero E
+
+	total, err := totalWeight(len(s), weights)
+	if err != nil {
+		return zero, err
+	}
+
+	target := r.Float64() * total
+	last := 0
+	for i, w := range weights {
+		if w == 0 {
+			continue
+		}
+		if target < w {
+			return s[i], nil
+		}
+		target -= w
+		last = i
+	}
+	// Rounding can leave a tiny remainder; it belongs to the last element with weight.
+	return s[last], nil
+}
+
+// WeightedSample returns k elements of the slice drawn independently at random using r, each with
+// probability proportional to its weight, so the same element can be drawn several times.
+// It uses the alias method: O(n) setup and O(1) per draw.
+//
+// Example:
+//
+//	r := rand.New(rand.NewPCG(1, 2))
+//	servers := []string{"big", "small"}
+//	picks, err := WeightedSample(servers, []float64{3, 1}, 1000, r)
+//	// about 750 of the picks are "big"
+func WeightedSample[S ~[]E, E any](s S, weights []float64, k int, r *rand.Rand) (S, error) {
+	if len(weights) != len(s) {
+		return nil, ErrInvalidWeights
+	}
+	table, err := NewAliasTable(weights)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(S, max(k, 0))
+	for i := range result {
+		result[i] = s[table.Next(r)]
+	}
+	return result, nil
+}
+
+// AliasTable draws indices with probability proportional to fixed weights in O(1) per draw,
+// using Vose's alias method. Build it once with NewAliasTable and reuse it for many draws.
+//
+// Example:
+//
+//	table, err := NewAliasTable([]float64{1, 2, 7})
+//	r := rand.New(rand.NewPCG(1, 2))
+//	i := table.Next(r)
+//	// i is 2 about 70% of the time
+type AliasTable struct {
+	prob  []float64
+	alias []int
+}
+
+// NewAliasTable builds an AliasTable from the weights.
+// It returns ErrInvalidWeights if a weight is negative or not finite, or if all weights are zero.
+func NewAliasTable(weights []float64) (*AliasTable, error) {
+	total, err := totalWeight(len(weights), weights)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(weights)
+	t := &AliasTable{prob: make([]float64, n), alias: make([]int, n)}
+	scaled := Map(weights, func(w float64) float64 {
+		return w * float64(n) / total
+	})
+
+	small, large := make([]int, 0), make([]int, 0)
+	for i, p := range scaled {
+		if p < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+		t.prob[s], t.alias[s] = scaled[s], l
+		scaled[l] -= 1 - scaled[s]
+		if scaled[l] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, l)
+		}
+	}
+	// Whatever is left is 1 up to rounding errors.
+	for _, i := range append(small, large...) {
+		t.prob[i], t.alias[i] = 1, i
+	}
+	return t, nil
+}
+
+// Len returns the number of weights in the table.
+func (t *AliasTable) Len() int {
+	return len(t.prob)
+}
+
+// Next returns a random index using r.
+func (t *AliasTable) Next(r *rand.Rand) int {
+	i := r.IntN(len(t.prob))
+	if r.Float64() < t.prob[i] {
+		return i
+	}
+	return t.alias[i]
+}
+
+func totalWeight(n int, weights []float64) (float64, error) {
+	if len(weights) != n || n == 0 {
+		return 0, ErrInvalidWeights
+	}
+
+	total := 0.0
+	for _, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return 0, ErrInvalidWeights
+		}
+		total += w
+	}
+	if total <= 0 || math.IsInf(total, 0) {
+		return 0, ErrInvalidWeights
+	}
+	return total, nil
+}
@@ -0,0 +1,128 @@
+package goassist
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+)
+
+// ErrIndexOutOfRange is wrapped by every *IndexError.
+var ErrIndexOutOfRange = errors.New("goassist: index out of range")
+
+// ErrEmpty is wrapped by every *EmptyError.
+var ErrEmpty = errors.New("goassist: empty slice")
+
+// IndexError reports indices that are invalid for a slice. Op is the name of the function that
+// failed. For TryInsert, Start and End are both the insertion index.
+type IndexError struct {
+	Op         string
+	Start, End int
+	Len        int
+}
+
+func (e *IndexError) Error() string {
+	if e.Start == e.End {
+		return fmt.Sprintf("%v: %s index %d with length %d", ErrIndexOutOfRange, e.Op, e.Start, e.Len)
+	}
+	return fmt.Sprintf("%v: %s range [%d:%d] with length %d", ErrIndexOutOfRange, e.Op, e.Start, e.End, e.Len)
+}
+
+func (e *IndexError) Unwrap() error {
+	return ErrIndexOutOfRange
+}
+
+// EmptyError reports an empty slice passed to a function that needs at least one element.
+// Op is the name of the function that failed.
+type EmptyError struct {
+	Op string
+}
+
+func (e *EmptyError) Error() string {
+	return ErrEmpty.Error() + ": " + e.Op
+}
+
+func (e *EmptyError) Unwrap() error {
+	return ErrEmpty
+}
+
+// TryDelete is like Delete but returns an *IndexError instead of panicking
+// unless 0 <= i <= j <= len(s).
+//
+// Example:
+//
+//	numbers := []int{1, 2, 3}
+//	_, err := TryDelete(numbers, 1, 5)
+//	// errors.Is(err, ErrIndexOutOfRange) is true
+func TryDelete[S ~[]E, E any](s S, i, j int) (S, error) {
+	if i < 0 || j < i || j > len(s) {
+		return s, &IndexError{Op: "TryDelete", Start: i, End: j, Len: len(s)}
+	}
+	return Delete(s, i, j), nil
+}
+
+// TryInsert is like Insert but returns an *IndexError instead of panicking
+// unless 0 <= i <= len(s).
+func TryInsert[S ~[]E, E any](s S, i int, v ...E) (S, error) {
+	if i < 0 || i > len(s) {
+		return s, &IndexError{Op: "TryInsert", Start: i, End: i, Len: len(s)}
+	}
+	return Insert(s, i, v...), nil
+}
+
+// TryReplace is like Replace but returns an *IndexError instead of panicking
+// unless 0 <= i <= j <= len(s).
+func TryReplace[S ~[]E, E any](s S, i, j int, v ...E) (S, error) {
+	if i < 0 || j < i || j > len(s) {
+		return s, &IndexError{Op: "TryReplace", Start: i, End: j, Len: len(s)}
+	}
+	return Replace(s, i, j, v...), nil
+}
+
+// TryMin is like Min but returns an *EmptyError instead of panicking if x is empty.
+//
+// Example:
+//
+//	_, err := TryMin([]int{})
+//	// errors.Is(err, ErrEmpty) is true
+func TryMin[S ~[]E, E cmp.Ordered](x S) (E, error) {
+	if len(x) == 0 {
+		var zero E
+		return zero, &EmptyError{Op: "TryMin"}
+	}
+	return Min(x), nil
+}
+
+// TryMax is like Max but returns an *EmptyError instead of panicking if x is empty.
+func TryMax[S ~[]E, E cmp.Ordered](x S) (E, error) {
+	if len(x) == 0 {
+		var zero E
+		return zero, &EmptyError{Op: "TryMax"}
+	}
+	return Max(x), nil
+}
+
+// Slice returns s[start:end] without panicking. Negative indices count from the end of the slice,
+// as in Python, and indices are then clamped to the bounds of the slice; if start ends up after
+// end, the result is empty. Like s[start:end], the result shares the backing array of s.
+//
+// Example:
+//
+//	numbers := []int{1, 2, 3, 4, 5}
+//	lastTwo := Slice(numbers, -2, len(numbers))
+//	// lastTwo is []int{4, 5}
+//	firstTen := Slice(numbers, 0, 10)
+//	// firstTen is []int{1, 2, 3, 4, 5}
+func Slice[S ~[]E, E any](s S, start, end int) S {
+	start, end = clampIndex(start, len(s)), clampIndex(end, len(s))
+	if start > end {
+		start = end
+	}
+	return s[start:end]
+}
+
+func clampIndex(i, n int) int {
+	if i < 0 {
+		i += n
+	}
+	return min(max(i, 0), n)
+}
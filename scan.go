@@ -0,0 +1,192 @@
+package goassist
+
+import "cmp"
+
+// Scan is like Reduce but returns every intermediate accumulator.
+// The result has the same length as the input and its last element equals the result of Reduce.
+//
+// Example:
+//
+//	deposits := []int{10, -5, 20}
+//	balances := Scan(deposits, func(acc, x int) int {
+//		return acc + x
+//	}, 100)
+//	// balances is []int{110, 105, 125}
+func Scan[T any, R any](arr []T, fn func(R, T) R, initial R) []R {
+	result := make([]R, len(arr))
+	acc := initial
+	for i, v := range arr {
+		acc = fn(acc, v)
+		result[i] = acc
+	}
+	return result
+}
+
+// ScanLeft is like Scan but also includes the initial value, so the result is one element longer than the input.
+//
+// Example:
+//
+//	deposits := []int{10, -5, 20}
+//	balances := ScanLeft(deposits, func(acc, x int) int {
+//		return acc + x
+//	}, 100)
+//	// balances is []int{100, 110, 105, 125}
+func ScanLeft[T any, R any](arr []T, fn func(R, T) R, initial R) []R {
+	result := make([]R, 0, len(arr)+1)
+	result = append(result, initial)
+	return append(result, Scan(arr, fn, initial)...)
+}
+
+// PrefixSum returns the running totals of the slice: result[i] is the sum of arr[0] through arr[i].
+//
+// Example:
+//
+//	visits := []int{3, 1, 4, 1, 5}
+//	totals := PrefixSum(visits)
+//	// totals is []int{3, 4, 8, 9, 14}
+func PrefixSum[T Number](arr []T) []T {
+	return Scan(arr, func(acc, x T) T {
+		return acc + x
+	}, 0)
+}
+
+// RunningMin returns the smallest element seen so far at every position of the slice.
+//
+// Example:
+//
+//	prices := []int{5, 3, 4, 1, 2}
+//	lows := RunningMin(prices)
+//	// lows is []int{5, 3, 3, 1, 1}
+func RunningMin[S ~[]E, E cmp.Ordered](x S) S {
+	result := make(S, len(x))
+	for i, v := range x {
+		if i == 0 {
+			result[i] = v
+			continue
+		}
+		result[i] = min(result[i-1], v)
+	}
+	return result
+}
+
+// RunningMax returns the largest element seen so far at every position of the slice.
+//
+// Example:
+//
+//	prices := []int{1, 3, 2, 5, 4}
+//	highs := RunningMax(prices)
+//	// highs is []int{1, 3, 3, 5, 5}
+func RunningMax[S ~[]E, E cmp.Ordered](x S) S {
+	result := make(S, len(x))
+	for i, v := range x {
+		if i == 0 {
+			result[i] = v
+			continue
+		}
+		result[i] = max(result[i-1], v)
+	}
+	return result
+}
+
+// PrefixSums answers range-sum queries over a fixed slice in O(1).
+//
+// Example:
+//
+//	sales := NewPrefixSums([]int{3, 1, 4, 1, 5})
+//	week := sales.RangeSum(1, 4)
+//	// week is 6 (1 + 4 + 1)
+type PrefixSums[T Number] struct {
+	sums []T
+}
+
+// NewPrefixSums precomputes the prefix sums of the slice in O(n).
+func NewPrefixSums[T Number](arr []T) *PrefixSums[T] {
+	sums := make([]T, len(arr)+1)
+	for i, v := range arr {
+		sums[i+1] = sums[i] + v
+	}
+	return &PrefixSums[T]{sums: sums}
+}
+
+// Len returns the length of the underlying slice.
+func (p *PrefixSums[T]) Len() int {
+	return len(p.sums) - 1
+}
+
+// RangeSum returns the sum of arr[i:j]. It panics if the range is out of bounds.
+func (p *PrefixSums[T]) RangeSum(i, j int) T {
+	if i < 0 || j > p.Len() || i > j {
+		panic("goassist.PrefixSums: range out of bounds")
+	}
+	return p.sums[j] - p.sums[i]
+}
+
+// FenwickTree (binary indexed tree) supports point updates and prefix or range sums in O(log n).
+//
+// Example:
+//
+//	stock := FenwickTreeFromSlice([]int{3, 1, 4, 1, 5})
+//	stock.Add(2, -2)
+//	total := stock.RangeSum(1, 4)
+//	// total is 4 (1 + 2 + 1)
+type FenwickTree[T Number] struct {
+	tree []T
+}
+
+// NewFenwickTree creates a FenwickTree over n zero values.
+func NewFenwickTree[T Number](n int) *FenwickTree[T] {
+	return &FenwickTree[T]{tree: make([]T, n+1)}
+}
+
+// FenwickTreeFromSlice creates a FenwickTree holding the elements of the slice in O(n).
+func FenwickTreeFromSlice[T Number](arr []T) *FenwickTree[T] {
+	f := NewFenwickTree[T](len(arr))
+	for i, v := range arr {
+		j := i + 1
+		f.tree[j] += v
+		if parent := j + j&-j; parent < len(f.tree) {
+			f.tree[parent] += f.tree[j]
+		}
+	}
+	return f
+}
+
+// Len returns the number of elements in the tree.
+func (f *FenwickTree[T]) Len() int {
+	return len(f.tree) - 1
+}
+
+// Add adds delta to the element at index i. It panics if i is out of bounds.
+func (f *FenwickTree[T]) Add(i int, delta T) {
+	if i < 0 || i >= f.Len() {
+		panic("goassist.FenwickTree: index out of bounds")
+	}
+	for j := i + 1; j < len(f.tree); j += j & -j {
+		f.tree[j] += delta
+	}
+}
+
+// Set replaces the element at index i with v. It panics if i is out of bounds.
+func (f *FenwickTree[T]) Set(i int, v T) {
+	f.Add(i, v-f.RangeSum(i, i+1))
+}
+
+// PrefixSum returns the sum of the first n elements. It panics if n is out of bounds.
+func (f *FenwickTree[T]) PrefixSum(n int) T {
+	if n < 0 || n > f.Len() {
+		panic("goassist.FenwickTree: index out of bounds")
+	}
+	var sum T
+	for j := n; j > 0; j -= j & -j {
+		sum += f.tree[j]
+	}
+	return sum
+}
+
+// RangeSum returns the sum of the elements with indices in [i, j). It panics if the range is out of bounds.
+func (f *FenwickTree[T]) RangeSum(i, j int) T {
+	if i > j {
+		panic("goassist.FenwickTree: range out of bounds")
+	}
+	return f.PrefixSum(j) - f.PrefixSum(i)
+}
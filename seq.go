@@ -0,0 +1,356 @@
+package goassist
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// MapSeq lazily applies a function to each element of the sequence.
+//
+// Example:
+//
+//	m := map[string]int{"alice": 25, "bob": 30}
+//	names := MapSeq(maps.Keys(m), strings.ToUpper)
+//	// names yields "ALICE" and "BOB" in map order
+func MapSeq[T any, R any](seq iter.Seq[T], fn func(T) R) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		for v := range seq {
+			if !yield(fn(v)) {
+				return
+			}
+		}
+	}
+}
+
+// FilterSeq lazily yields only the elements of the sequence that satisfy the predicate function.
+//
+// Example:
+//
+//	numbers := []int{1, 2, 3, 4, 5}
+//	evens := FilterSeq(slices.Values(numbers), func(x int) bool {
+//		return x%2 == 0
+//	})
+//	// evens yields 2, 4
+func FilterSeq[T any](seq iter.Seq[T], fn func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if fn(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// FlattenSeq lazily flattens a sequence of sequences into a single sequence.
+//
+// Example:
+//
+//	groups := map[string][]int{"a": {1, 2}, "b": {3}}
+//	all := FlattenSeq(MapSeq(maps.Values(groups), slices.Values[[]int]))
+//	// all yields 1, 2, 3 (groups in map order)
+func FlattenSeq[T any](seq iter.Seq[iter.Seq[T]]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for inner := range seq {
+			for v := range inner {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// UntilError turns a sequence of values and errors into a sequence of values, so that it can be
+// used with MapSeq, FilterSeq and the other sequence helpers. It stops at the first error and
+// stores it in *err; check *err once the sequence has been consumed.
+//
+// Example:
+//
+//	var err error
+//	events := UntilError(DecodeJSONSeq[Event](file), &err)
+//	errorsOnly := FilterSeq(events, func(e Event) bool { return e.Level == "error" })
+//	count := ReduceSeq(errorsOnly, func(n int, _ Event) int { return n + 1 }, 0)
+//	if err != nil {
+//		return err
+//	}
+func UntilError[T any](seq iter.Seq2[T, error], err *error) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v, e := range seq {
+			if e != nil {
+				*err = e
+				return
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ReduceSeq applies a function cumulatively to the elements of the sequence, reducing it to a single value.
+//
+// Example:
+//
+//	m := map[string]int{"alice": 25, "bob": 30}
+//	total := ReduceSeq(maps.Values(m), func(acc, x int) int {
+//		return acc + x
+//	}, 0)
+//	// total is 55
+func ReduceSeq[T any, R any](seq iter.Seq[T], fn func(R, T) R, initial R) R {
+	result := initial
+	for v := range seq {
+		result = fn(result, v)
+	}
+	return result
+}
+
+// FindSeq returns the first element of the sequence that satisfies the predicate function
+// and a boolean indicating success. The sequence is not consumed past the match.
+//
+// Example:
+//
+//	numbers := []int{1, 2, 3, 4, 5}
+//	first, found := FindSeq(slices.Values(numbers), func(x int) bool {
+//		return x > 3
+//	})
+//	// first is 4, found is true
+func FindSeq[T any](seq iter.Seq[T], fn func(T) bool) (T, bool) {
+	for v := range seq {
+		if fn(v) {
+			return v, true
+		}
+	}
+
+	var zero T
+
+	return zero, false
+}
+
+// SomeSeq returns true if at least one element of the sequence satisfies the predicate function.
+//
+// Example:
+//
+//	m := map[string]int{"alice": 25, "bob": 30}
+//	hasThirty := SomeSeq(maps.Values(m), func(age int) bool {
+//		return age >= 30
+//	})
+//	// hasThirty is true
+func SomeSeq[T any](seq iter.Seq[T], fn func(T) bool) bool {
+	for v := range seq {
+		if fn(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// EverySeq returns true if all elements of the sequence satisfy the predicate function.
+//
+// Example:
+//
+//	m := map[string]int{"alice": 25, "bob": 30}
+//	allAdults := EverySeq(maps.Values(m), func(age int) bool {
+//		return age >= 18
+//	})
+//	// allAdults is true
+func EverySeq[T any](seq iter.Seq[T], fn func(T) bool) bool {
+	for v := range seq {
+		if !fn(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsSeq checks if a value exists in the sequence.
+//
+// Example:
+//
+//	m := map[string]int{"alice": 25, "bob": 30}
+//	exists := ContainsSeq(maps.Keys(m), "bob")
+//	// exists is true
+func ContainsSeq[E comparable](seq iter.Seq[E], v E) bool {
+	return IndexSeq(seq, v) >= 0
+}
+
+// ContainsFuncSeq checks if any element of the sequence satisfies a predicate function.
+//
+// Example:
+//
+//	m := map[string]int{"alice": 25, "bob": 30}
+//	hasLong := ContainsFuncSeq(maps.Keys(m), func(s string) bool {
+//		return len(s) > 4
+//	})
+//	// hasLong is true
+func ContainsFuncSeq[E any](seq iter.Seq[E], f func(E) bool) bool {
+	return IndexFuncSeq(seq, f) >= 0
+}
+
+// IndexSeq returns the position of the first occurrence of v in the sequence, or -1 if not present.
+//
+// Example:
+//
+//	numbers := []int{1, 2, 3, 2, 4}
+//	index := IndexSeq(slices.Values(numbers), 2)
+//	// index is 1
+func IndexSeq[E comparable](seq iter.Seq[E], v E) int {
+	return IndexFuncSeq(seq, func(e E) bool {
+		return e == v
+	})
+}
+
+// IndexFuncSeq returns the position of the first element of the sequence satisfying f, or -1 if none do.
+//
+// Example:
+//
+//	numbers := []int{1, 2, 3, 4, 5}
+//	index := IndexFuncSeq(slices.Values(numbers), func(x int) bool {
+//		return x%2 == 0
+//	})
+//	// index is 1 (first even number)
+func IndexFuncSeq[E any](seq iter.Seq[E], f func(E) bool) int {
+	i := 0
+	for v := range seq {
+		if f(v) {
+			return i
+		}
+		i++
+	}
+	return -1
+}
+
+// MaxSeq returns the maximum element of the sequence. It panics if the sequence is empty.
+// For floating-point numbers, MaxSeq propagates NaNs like Max.
+//
+// Example:
+//
+//	m := map[string]int{"alice": 25, "bob": 30}
+//	oldest := MaxSeq(maps.Values(m))
+//	// oldest is 30
+func MaxSeq[E cmp.Ordered](seq iter.Seq[E]) E {
+	result, ok := extremeSeq(seq, func(acc, v E) E {
+		return max(acc, v)
+	})
+	if !ok {
+		panic("goassist.MaxSeq: empty sequence")
+	}
+	return result
+}
+
+// MaxFuncSeq returns the maximum element of the sequence using cmp. It panics if the sequence is empty.
+// If there are several maximal elements, the first one is returned.
+//
+// Example:
+//
+//	type Person struct {
+//		Name string
+//		Age  int
+//	}
+//	people := map[int]Person{1: {"Alice", 25}, 2: {"Bob", 30}}
+//	oldest := MaxFuncSeq(maps.Values(people), func(a, b Person) int {
+//		return a.Age - b.Age
+//	})
+//	// oldest is Person{"Bob", 30}
+func MaxFuncSeq[E any](seq iter.Seq[E], cmp func(a, b E) int) E {
+	result, ok := extremeSeq(seq, func(acc, v E) E {
+		if cmp(v, acc) > 0 {
+			return v
+		}
+		return acc
+	})
+	if !ok {
+		panic("goassist.MaxFuncSeq: empty sequence")
+	}
+	return result
+}
+
+// MinSeq returns the minimum element of the sequence. It panics if the sequence is empty.
+// For floating-point numbers, MinSeq propagates NaNs like Min.
+//
+// Example:
+//
+//	m := map[string]int{"alice": 25, "bob": 30}
+//	youngest := MinSeq(maps.Values(m))
+//	// youngest is 25
+func MinSeq[E cmp.Ordered](seq iter.Seq[E]) E {
+	result, ok := extremeSeq(seq, func(acc, v E) E {
+		return min(acc, v)
+	})
+	if !ok {
+		panic("goassist.MinSeq: empty sequence")
+	}
+	return result
+}
+
+// MinFuncSeq returns the minimum element of the sequence using cmp. It panics if the sequence is empty.
+// If there are several minimal elements, the first one is returned.
+//
+// Example:
+//
+//	type Person struct {
+//		Name string
+//		Age  int
+//	}
+//	people := map[int]Person{1: {"Alice", 25}, 2: {"Bob", 30}}
+//	youngest := MinFuncSeq(maps.Values(people), func(a, b Person) int {
+//		return a.Age - b.Age
+//	})
+//	// youngest is Person{"Alice", 25}
+func MinFuncSeq[E any](seq iter.Seq[E], cmp func(a, b E) int) E {
+	result, ok := extremeSeq(seq, func(acc, v E) E {
+		if cmp(v, acc) < 0 {
+			return v
+		}
+		return acc
+	})
+	if !ok {
+		panic("goassist.MinFuncSeq: empty sequence")
+	}
+	return result
+}
+
+func extremeSeq[E any](seq iter.Seq[E], pick func(acc, v E) E) (E, bool) {
+	var result E
+	found := false
+	for v := range seq {
+		if !found {
+			result, found = v, true
+			continue
+		}
+		result = pick(result, v)
+	}
+	return result, found
+}
+
+// SortedCollect collects the sequence into a new slice sorted in ascending order,
+// ready to be used with BinarySearch.
+//
+// Example:
+//
+//	m := map[string]int{"charlie": 35, "alice": 25, "bob": 30}
+//	names := SortedCollect(maps.Keys(m))
+//	// names is []string{"alice", "bob", "charlie"}
+//	index, found := BinarySearch(names, "bob")
+//	// index is 1, found is true
+func SortedCollect[E cmp.Ordered](seq iter.Seq[E]) []E {
+	return slices.Sorted(seq)
+}
+
+// SortedCollectFunc collects the sequence into a new slice sorted using a custom comparison function,
+// ready to be used with BinarySearchFunc.
+//
+// Example:
+//
+//	type Person struct {
+//		Name string
+//		Age  int
+//	}
+//	people := map[int]Person{1: {"Bob", 30}, 2: {"Alice", 25}}
+//	byAge := SortedCollectFunc(maps.Values(people), func(a, b Person) int {
+//		return a.Age - b.Age
+//	})
+//	// byAge is []Person{{"Alice", 25}, {"Bob", 30}}
+func SortedCollectFunc[E any](seq iter.Seq[E], cmp func(a, b E) int) []E {
+	return slices.SortedFunc(seq, cmp)
+}
@@ -0,0 +1,239 @@
+package goassist
+
+import (
+	"cmp"
+	"slices"
+)
+
+// LowerBound returns the index of the first element of the sorted slice that is not less than target,
+// or len(s) if there is none.
+//
+// Example:
+//
+//	numbers := []int{1, 3, 3, 5}
+//	i := LowerBound(numbers, 3)
+//	// i is 1
+func LowerBound[S ~[]E, E cmp.Ordered](s S, target E) int {
+	i, _ := slices.BinarySearch(s, target)
+	return i
+}
+
+// LowerBoundFunc is like LowerBound but uses a custom comparison function, as BinarySearchFunc does.
+func LowerBoundFunc[S ~[]E, E, T any](s S, target T, cmp func(E, T) int) int {
+	i, _ := slices.BinarySearchFunc(s, target, cmp)
+	return i
+}
+
+// UpperBound returns the index of the first element of the sorted slice that is greater than target,
+// or len(s) if there is none.
+//
+// Example:
+//
+//	numbers := []int{1, 3, 3, 5}
+//	i := UpperBound(numbers, 3)
+//	// i is 3
+func UpperBound[S ~[]E, E cmp.Ordered](s S, target E) int {
+	return UpperBoundFunc(s, target, cmp.Compare[E])
+}
+
+// UpperBoundFunc is like UpperBound but uses a custom comparison function, as BinarySearchFunc does.
+func UpperBoundFunc[S ~[]E, E, T any](s S, target T, cmp func(E, T) int) int {
+	lo, hi := 0, len(s)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if cmp(s[mid], target) <= 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// InsertSorted inserts v into the sorted slice, keeping it sorted.
+//
+// Example:
+//
+//	numbers := []int{1, 3, 5}
+//	numbers = InsertSorted(numbers, 4)
+//	// numbers is []int{1, 3, 4, 5}
+func InsertSorted[S ~[]E, E cmp.Ordered](s S, v E) S {
+	i, _ := BinarySearch(s, v)
+	return Insert(s, i, v)
+}
+
+// InsertSortedFunc inserts v into a slice sorted by cmp, keeping it sorted.
+//
+// Example:
+//
+//	type Person struct {
+//		Name string
+//		Age  int
+//	}
+//	people := []Person{{"Alice", 25}, {"Charlie", 35}}
+//	people = InsertSortedFunc(people, Person{"Bob", 30}, func(a, b Person) int {
+//		return a.Age - b.Age
+//	})
+//	// people is sorted by age with Bob in the middle
+func InsertSortedFunc[S ~[]E, E any](s S, v E, cmp func(a, b E) int) S {
+	i, _ := BinarySearchFunc(s, v, cmp)
+	return Insert(s, i, v)
+}
+
+// RemoveSorted removes one occurrence of v from the sorted slice and reports whether it was found.
+//
+// Example:
+//
+//	numbers := []int{1, 3, 3, 5}
+//	numbers, removed := RemoveSorted(numbers, 3)
+//	// numbers is []int{1, 3, 5}, removed is true
+func RemoveSorted[S ~[]E, E cmp.Ordered](s S, v E) (S, bool) {
+	i, found := BinarySearch(s, v)
+	if !found {
+		return s, false
+	}
+	return Delete(s, i, i+1), true
+}
+
+// RemoveSortedFunc removes one element matching target from a slice sorted by cmp
+// and reports whether it was found.
+func RemoveSortedFunc[S ~[]E, E, T any](s S, target T, cmp func(E, T) int) (S, bool) {
+	i, found := BinarySearchFunc(s, target, cmp)
+	if !found {
+		return s, false
+	}
+	return Delete(s, i, i+1), true
+}
+
+// RangeSorted returns the subslice of the sorted slice holding the elements in [lo, hi).
+// The result shares the backing array of s.
+//
+// Example:
+//
+//	scores := []int{10, 20, 30, 40, 50}
+//	passing := RangeSorted(scores, 20, 40)
+//	// passing is []int{20, 30}
+func RangeSorted[S ~[]E, E cmp.Ordered](s S, lo, hi E) S {
+	if hi <= lo {
+		return s[:0:0]
+	}
+	return s[LowerBound(s, lo):LowerBound(s, hi)]
+}
+
+// RangeSortedFunc is like RangeSorted but uses a custom comparison function, as BinarySearchFunc does.
+func RangeSortedFunc[S ~[]E, E, T any](s S, lo, hi T, cmp func(E, T) int) S {
+	i, j := LowerBoundFunc(s, lo, cmp), LowerBoundFunc(s, hi, cmp)
+	if j <= i {
+		return s[:0:0]
+	}
+	return s[i:j]
+}
+
+// MergeSorted merges any number of sorted slices into a new sorted slice using a heap,
+// in O(n log k) for k slices holding n elements. Equal elements keep the order of the input slices.
+//
+// Example:
+//
+//	merged := MergeSorted([]int{1, 4, 7}, []int{2, 5}, []int{3, 6})
+//	// merged is []int{1, 2, 3, 4, 5, 6, 7}
+func MergeSorted[S ~[]E, E cmp.Ordered](ss ...S) S {
+	return MergeSortedFunc(cmp.Compare[E], ss...)
+}
+
+// MergeSortedFunc is like MergeSorted for slices sorted by cmp.
+func MergeSortedFunc[S ~[]E, E any](cmp func(a, b E) int, ss ...S) S {
+	type cursor struct {
+		slice int
+		index int
+	}
+
+	total := 0
+	pq := newPriorityQueue(func(a, b cursor) bool {
+		if c := cmp(ss[a.slice][a.index], ss[b.slice][b.index]); c != 0 {
+			return c < 0
+		}
+		return a.slice < b.slice
+	})
+	for i, s := range ss {
+		total += len(s)
+		if len(s) > 0 {
+			pq.push(cursor{slice: i})
+		}
+	}
+
+	result := make(S, 0, total)
+	for pq.len() > 0 {
+		c := pq.pop()
+		result = append(result, ss[c.slice][c.index])
+		if c.index+1 < len(ss[c.slice]) {
+			pq.push(cursor{slice: c.slice, index: c.index + 1})
+		}
+	}
+	return result
+}
+
+// IntersectSorted returns the elements present in both sorted slices, in linear time.
+// An element occurring several times is kept as often as it occurs in both slices.
+//
+// Example:
+//
+//	a := []int{1, 2, 2, 3, 5}
+//	b := []int{2, 2, 4, 5}
+//	common := IntersectSorted(a, b)
+//	// common is []int{2, 2, 5}
+func IntersectSorted[S ~[]E, E cmp.Ordered](a, b S) S {
+	return IntersectSortedFunc(a, b, cmp.Compare[E])
+}
+
+// IntersectSortedFunc is like IntersectSorted for slices sorted by cmp.
+func IntersectSortedFunc[S ~[]E, E any](a, b S, cmp func(a, b E) int) S {
+	result := make(S, 0)
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch c := cmp(a[i], b[j]); {
+		case c < 0:
+			i++
+		case c > 0:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// UnionSorted returns the sorted union of two sorted slices, in linear time.
+// An element occurring several times is kept as often as it occurs in either slice.
+//
+// Example:
+//
+//	a := []int{1, 2, 2, 5}
+//	b := []int{2, 3, 5}
+//	all := UnionSorted(a, b)
+//	// all is []int{1, 2, 2, 3, 5}
+func UnionSorted[S ~[]E, E cmp.Ordered](a, b S) S {
+	return UnionSortedFunc(a, b, cmp.Compare[E])
+}
+
+// UnionSortedFunc is like UnionSorted for slices sorted by cmp.
+func UnionSortedFunc[S ~[]E, E any](a, b S, cmp func(a, b E) int) S {
+	result := make(S, 0, max(len(a), len(b)))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch c := cmp(a[i], b[j]); {
+		case c < 0:
+			result = append(result, a[i])
+			i++
+		case c > 0:
+			result = append(result, b[j])
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	result = append(result, a[i:]...)
+	return append(result, b[j:]...)
+}
@@ -0,0 +1,165 @@
+package goassist
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+)
+
+// Lines returns an iterator over the lines read from r, without their line endings ("\n" or "\r\n").
+// A final line without a line ending is yielded too, and lines may be of any length.
+// Reading stops at the first error, which is yielded with an empty line. The sequence reads r
+// and can only be iterated once. Use UntilError to feed the lines to MapSeq, FilterSeq and the
+// other helpers.
+//
+// Example:
+//
+//	var err error
+//	lines := UntilError(Lines(file), &err)
+//	failures := FilterSeq(lines, func(line string) bool {
+//		return strings.Contains(line, "ERROR")
+//	})
+//	for line := range failures {
+//		fmt.Println(line)
+//	}
+//	if err != nil {
+//		return err
+//	}
+func Lines(r io.Reader) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		br := bufio.NewReader(r)
+		for {
+			line, err := br.ReadString('\n')
+			if len(line) > 0 {
+				if trimmed, ok := strings.CutSuffix(line, "\n"); ok {
+					line = strings.TrimSuffix(trimmed, "\r")
+				}
+				if !yield(line, nil) {
+					return
+				}
+			}
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield("", err)
+				return
+			}
+		}
+	}
+}
+
+// CSVRecords returns an iterator over the CSV records read from r, header row included.
+// Every record must have as many fields as the first one. To avoid an allocation per record, the
+// same slice is yielded on every iteration: it is only valid until the next iteration, so Clone
+// it to keep it. Reading stops at the first error, which is yielded with a nil record.
+//
+// Example:
+//
+//	var err error
+//	records := UntilError(CSVRecords(file), &err)
+//	total := ReduceSeq(records, func(sum int, record []string) int {
+//		return sum + len(record)
+//	}, 0)
+func CSVRecords(r io.Reader) iter.Seq2[[]string, error] {
+	return func(yield func([]string, error) bool) {
+		cr := csv.NewReader(r)
+		cr.ReuseRecord = true
+		for {
+			record, err := cr.Read()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(record, nil) {
+				return
+			}
+		}
+	}
+}
+
+// NDJSON returns an iterator over the JSON values read from r, one per line (newline-delimited JSON).
+// Every non-blank line must hold exactly one JSON value; blank lines are skipped. Decoding stops
+// at the first error, which is yielded with the zero value of T and names the line for decoding
+// errors. Use EncodeJSONSeq with JSONLines to write NDJSON.
+//
+// Example:
+//
+//	for event, err := range NDJSON[Event](file) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(event.Level, event.Message)
+//	}
+func NDJSON[T any](r io.Reader) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		n := 0
+		for line, err := range Lines(r) {
+			var zero T
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			n++
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			var v T
+			if err := json.Unmarshal([]byte(line), &v); err != nil {
+				yield(zero, fmt.Errorf("goassist: NDJSON line %d: %w", n, err))
+				return
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// WriteLines writes every string of the sequence to w followed by "\n", buffering the output.
+//
+// Example:
+//
+//	var err error
+//	lines := UntilError(Lines(in), &err)
+//	if werr := WriteLines(out, MapSeq(lines, strings.ToUpper)); werr != nil {
+//		return werr
+//	}
+func WriteLines(w io.Writer, seq iter.Seq[string]) error {
+	// bufio.Writer keeps the first write error and returns it from every later call.
+	bw := bufio.NewWriter(w)
+	for line := range seq {
+		bw.WriteString(line)
+		if err := bw.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// WriteCSVRecords writes every record of the sequence to w as CSV, buffering the output.
+// It works with the reused slices yielded by CSVRecords.
+//
+// Example:
+//
+//	var err error
+//	records := UntilError(CSVRecords(in), &err)
+//	paid := FilterSeq(records, func(record []string) bool { return record[2] != "unpaid" })
+//	werr := WriteCSVRecords(out, paid)
+func WriteCSVRecords(w io.Writer, seq iter.Seq[[]string]) error {
+	cw := csv.NewWriter(w)
+	for record := range seq {
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
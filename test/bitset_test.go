@@ -0,0 +1,147 @@
+package goassist_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+func TestBitSet(t *testing.T) {
+	var flags goassist.BitSet
+	flags.Set(1)
+	flags.Set(4)
+	flags.Set(130)
+	if !flags.Test(4) || !flags.Test(130) || flags.Test(2) || flags.Test(-1) || flags.Test(1000) {
+		t.Error("BitSet failed: unexpected Test result")
+	}
+	if flags.Count() != 3 {
+		t.Errorf("BitSet failed: expected count 3, got %d", flags.Count())
+	}
+	flags.Clear(4)
+	flags.Clear(1000)
+	if flags.Test(4) || flags.Count() != 2 {
+		t.Errorf("BitSet failed: expected 4 to be cleared, got %v", flags.ToSlice())
+	}
+}
+
+func TestBitSetFromSlice(t *testing.T) {
+	flags := goassist.BitSetFromSlice([]int{64, 3, 1, 3, 7})
+	ids := flags.ToSlice()
+	expected := []int{1, 3, 7, 64}
+	if !slices.Equal(ids, expected) {
+		t.Errorf("BitSetFromSlice failed: expected %v, got %v", expected, ids)
+	}
+	if !goassist.IsSorted(ids) {
+		t.Error("BitSetFromSlice failed: expected sorted slice")
+	}
+	if index, found := goassist.BinarySearch(ids, 7); !found || index != 2 {
+		t.Errorf("BitSetFromSlice failed: expected 7 at 2, got %d, found %v", index, found)
+	}
+}
+
+func TestBitSetSetOperations(t *testing.T) {
+	a := goassist.BitSetFromSlice([]int{1, 2, 100})
+	b := goassist.BitSetFromSlice([]int{2, 3})
+
+	if got := a.Union(b).ToSlice(); !slices.Equal(got, []int{1, 2, 3, 100}) {
+		t.Errorf("Union failed: expected [1 2 3 100], got %v", got)
+	}
+	if got := a.Intersect(b).ToSlice(); !slices.Equal(got, []int{2}) {
+		t.Errorf("Intersect failed: expected [2], got %v", got)
+	}
+	if got := a.Difference(b).ToSlice(); !slices.Equal(got, []int{1, 100}) {
+		t.Errorf("Difference failed: expected [1 100], got %v", got)
+	}
+	if got := a.ToSlice(); !slices.Equal(got, []int{1, 2, 100}) {
+		t.Errorf("Union failed: expected receiver to be unchanged, got %v", got)
+	}
+	if !a.Intersect(b).Equal(goassist.BitSetFromSlice([]int{2})) {
+		t.Error("Equal failed: expected sets with different capacities to be equal")
+	}
+}
+
+func TestBitSetAll(t *testing.T) {
+	flags := goassist.BitSetFromSlice([]int{7, 1, 3})
+	ids := []int{}
+	for id := range flags.All() {
+		ids = append(ids, id)
+		if id == 3 {
+			break
+		}
+	}
+	if !slices.Equal(ids, []int{1, 3}) {
+		t.Errorf("All failed: expected [1 3], got %v", ids)
+	}
+}
+
+func TestBitSetBinary(t *testing.T) {
+	flags := goassist.BitSetFromSlice([]int{0, 5, 200})
+	flags.Clear(200)
+	data, err := flags.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	if len(data) != 9 {
+		t.Errorf("MarshalBinary failed: expected 9 bytes, got %d", len(data))
+	}
+
+	var decoded goassist.BitSet
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if !decoded.Equal(flags) {
+		t.Errorf("UnmarshalBinary failed: expected %v, got %v", flags.ToSlice(), decoded.ToSlice())
+	}
+	if err := decoded.UnmarshalBinary(data[:5]); err == nil {
+		t.Error("UnmarshalBinary failed: expected error for truncated input")
+	}
+	// 8*(1<<61) overflows to 0, which used to pass the length check and panic in make.
+	if err := decoded.UnmarshalBinary(binary.AppendUvarint(nil, 1<<61)); !errors.Is(err, goassist.ErrInvalidBitSetEncoding) {
+		t.Errorf("UnmarshalBinary failed: expected ErrInvalidBitSetEncoding for huge word count, got %v", err)
+	}
+	if err := decoded.UnmarshalBinary([]byte{0, 1, 2, 3}); !errors.Is(err, goassist.ErrInvalidBitSetEncoding) {
+		t.Errorf("UnmarshalBinary failed: expected ErrInvalidBitSetEncoding for partial word, got %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(flags); err != nil {
+		t.Fatalf("gob encode failed: %v", err)
+	}
+	var viaGob goassist.BitSet
+	if err := gob.NewDecoder(&buf).Decode(&viaGob); err != nil || !viaGob.Equal(flags) {
+		t.Errorf("gob round trip failed: got %v, %v", viaGob.ToSlice(), err)
+	}
+}
+
+func TestBitSetJSON(t *testing.T) {
+	flags := goassist.BitSetFromSlice([]int{130, 4, 1})
+	data, err := json.Marshal(flags)
+	if err != nil || string(data) != "[1,4,130]" {
+		t.Fatalf("MarshalJSON failed: got %s, %v", data, err)
+	}
+	if empty, _ := json.Marshal(&goassist.BitSet{}); string(empty) != "[]" {
+		t.Errorf("MarshalJSON failed: expected [], got %s", empty)
+	}
+
+	decoded := goassist.BitSetFromSlice([]int{7})
+	if err := json.Unmarshal(data, decoded); err != nil || !decoded.Equal(flags) {
+		t.Errorf("UnmarshalJSON failed: expected %v, got %v, %v", flags.ToSlice(), decoded.ToSlice(), err)
+	}
+	if err := json.Unmarshal([]byte("[1,-2]"), decoded); !errors.Is(err, goassist.ErrInvalidBitSetEncoding) {
+		t.Errorf("UnmarshalJSON failed: expected ErrInvalidBitSetEncoding, got %v", err)
+	}
+	if err := json.Unmarshal([]byte("[1,9000000000000000000]"), decoded); !errors.Is(err, goassist.ErrInvalidBitSetEncoding) {
+		t.Errorf("UnmarshalJSON failed: expected ErrInvalidBitSetEncoding for huge member, got %v", err)
+	}
+	limit := fmt.Sprintf("[%d]", goassist.MaxBitSetJSONID)
+	if err := json.Unmarshal([]byte(limit), decoded); err != nil || !decoded.Test(goassist.MaxBitSetJSONID) {
+		t.Errorf("UnmarshalJSON failed: expected MaxBitSetJSONID to be accepted, got %v", err)
+	}
+}
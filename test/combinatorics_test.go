@@ -0,0 +1,130 @@
+package goassist_test
+
+import (
+	"fmt"
+	"iter"
+	"testing"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+func collectAll[S ~[]E, E any](seq iter.Seq[S]) []S {
+	result := []S{}
+	for s := range seq {
+		result = append(result, goassist.Clone(s))
+	}
+	return result
+}
+
+func assertStrictlyIncreasing(t *testing.T, name string, all [][]int) {
+	t.Helper()
+	for i := 1; i < len(all); i++ {
+		if goassist.Compare(all[i-1], all[i]) >= 0 {
+			t.Errorf("%s failed: expected %v < %v", name, all[i-1], all[i])
+		}
+	}
+}
+
+func TestPermutations(t *testing.T) {
+	all := collectAll(goassist.Permutations([]int{1, 2, 3}))
+	expected := "[[1 2 3] [1 3 2] [2 1 3] [2 3 1] [3 1 2] [3 2 1]]"
+	if got := fmt.Sprint(all); got != expected {
+		t.Errorf("Permutations failed: expected %s, got %s", expected, got)
+	}
+
+	four := collectAll(goassist.PermutationsBuf([]int{1, 2, 3, 4}))
+	if len(four) != 24 {
+		t.Errorf("PermutationsBuf failed: expected 24 permutations, got %d", len(four))
+	}
+	assertStrictlyIncreasing(t, "PermutationsBuf", four)
+
+	if empty := collectAll(goassist.Permutations([]int{})); len(empty) != 1 || len(empty[0]) != 0 {
+		t.Errorf("Permutations failed: expected one empty permutation, got %v", empty)
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	all := collectAll(goassist.Combinations([]string{"a", "b", "c", "d"}, 2))
+	expected := "[[a b] [a c] [a d] [b c] [b d] [c d]]"
+	if got := fmt.Sprint(all); got != expected {
+		t.Errorf("Combinations failed: expected %s, got %s", expected, got)
+	}
+
+	numbers := collectAll(goassist.CombinationsBuf([]int{1, 2, 3, 4, 5, 6}, 3))
+	if len(numbers) != 20 {
+		t.Errorf("CombinationsBuf failed: expected 20 combinations, got %d", len(numbers))
+	}
+	assertStrictlyIncreasing(t, "CombinationsBuf", numbers)
+
+	if none := collectAll(goassist.Combinations([]int{1, 2}, 3)); len(none) != 0 {
+		t.Errorf("Combinations failed: expected no combinations, got %v", none)
+	}
+	if zero := collectAll(goassist.Combinations([]int{1, 2}, 0)); len(zero) != 1 {
+		t.Errorf("Combinations failed: expected one empty combination, got %v", zero)
+	}
+}
+
+func TestCombinationsWithReplacement(t *testing.T) {
+	all := collectAll(goassist.CombinationsWithReplacement([]string{"a", "b", "c"}, 2))
+	expected := "[[a a] [a b] [a c] [b b] [b c] [c c]]"
+	if got := fmt.Sprint(all); got != expected {
+		t.Errorf("CombinationsWithReplacement failed: expected %s, got %s", expected, got)
+	}
+	numbers := collectAll(goassist.CombinationsWithReplacementBuf([]int{1, 2, 3, 4}, 3))
+	if len(numbers) != 20 {
+		t.Errorf("CombinationsWithReplacementBuf failed: expected 20 combinations, got %d", len(numbers))
+	}
+	assertStrictlyIncreasing(t, "CombinationsWithReplacementBuf", numbers)
+}
+
+func TestPowerSet(t *testing.T) {
+	all := collectAll(goassist.PowerSet([]int{1, 2, 3}))
+	expected := "[[] [1] [1 2] [1 2 3] [1 3] [2] [2 3] [3]]"
+	if got := fmt.Sprint(all); got != expected {
+		t.Errorf("PowerSet failed: expected %s, got %s", expected, got)
+	}
+	five := collectAll(goassist.PowerSetBuf([]int{1, 2, 3, 4, 5}))
+	if len(five) != 32 {
+		t.Errorf("PowerSetBuf failed: expected 32 subsets, got %d", len(five))
+	}
+	assertStrictlyIncreasing(t, "PowerSetBuf", five)
+}
+
+func TestCartesianProduct(t *testing.T) {
+	all := collectAll(goassist.CartesianProduct([]string{"linux", "darwin"}, []string{"amd64", "arm64"}))
+	expected := "[[linux amd64] [linux arm64] [darwin amd64] [darwin arm64]]"
+	if got := fmt.Sprint(all); got != expected {
+		t.Errorf("CartesianProduct failed: expected %s, got %s", expected, got)
+	}
+
+	numbers := collectAll(goassist.CartesianProductBuf([]int{1, 2}, []int{1, 2, 3}, []int{5, 6}))
+	if len(numbers) != 12 {
+		t.Errorf("CartesianProductBuf failed: expected 12 tuples, got %d", len(numbers))
+	}
+	assertStrictlyIncreasing(t, "CartesianProductBuf", numbers)
+
+	if none := collectAll(goassist.CartesianProduct([]int{1}, []int{})); len(none) != 0 {
+		t.Errorf("CartesianProduct failed: expected no tuples, got %v", none)
+	}
+}
+
+func TestCombinatoricsBufReuse(t *testing.T) {
+	var first []int
+	for c := range goassist.CombinationsBuf([]int{1, 2, 3}, 2) {
+		if first == nil {
+			first = c
+			continue
+		}
+		if &first[0] != &c[0] {
+			t.Error("CombinationsBuf failed: expected buffer to be reused")
+		}
+		break
+	}
+	allocs := testing.AllocsPerRun(10, func() {
+		for range goassist.PermutationsBuf([]int{1, 2, 3, 4, 5}) {
+		}
+	})
+	if allocs > 4 {
+		t.Errorf("PermutationsBuf failed: expected constant allocations, got %v", allocs)
+	}
+}
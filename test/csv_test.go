@@ -0,0 +1,161 @@
+package goassist_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+type csvAudit struct {
+	CreatedAt time.Time `csv:"created_at"`
+	UpdatedBy *string   `csv:"updated_by"`
+}
+
+type csvUser struct {
+	ID       int     `csv:"id"`
+	Name     string  `csv:"name"`
+	Score    float64 `csv:"score"`
+	Active   bool
+	Password string `csv:"-"`
+	internal int
+	csvAudit
+}
+
+func TestToCSV(t *testing.T) {
+	admin := "admin"
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	users := []csvUser{
+		{ID: 1, Name: "Alice", Score: 9.5, Active: true, Password: "secret", csvAudit: csvAudit{CreatedAt: created, UpdatedBy: &admin}},
+		{ID: 2, Name: "Bob, Jr.", Score: 7, csvAudit: csvAudit{CreatedAt: created}},
+		{ID: 3, Name: "Carol", Active: true, csvAudit: csvAudit{CreatedAt: created}},
+	}
+
+	var sb strings.Builder
+	active := goassist.Filter(users, func(u csvUser) bool { return u.Active })
+	if err := goassist.ToCSV(&sb, active); err != nil {
+		t.Fatalf("ToCSV failed: %v", err)
+	}
+	expected := "id,name,score,Active,created_at,updated_by\n" +
+		"1,Alice,9.5,true,2024-05-01T12:00:00Z,admin\n" +
+		"3,Carol,0,true,2024-05-01T12:00:00Z,\n"
+	if sb.String() != expected {
+		t.Errorf("ToCSV failed: expected\n%s\ngot\n%s", expected, sb.String())
+	}
+
+	sb.Reset()
+	goassist.ToCSV(&sb, users)
+	decoded, err := goassist.FromCSV[csvUser](strings.NewReader(sb.String()))
+	if err != nil {
+		t.Fatalf("FromCSV failed: %v", err)
+	}
+	for i, u := range decoded {
+		want := users[i]
+		want.Password = ""
+		if u.ID != want.ID || u.Name != want.Name || u.Score != want.Score || u.Active != want.Active ||
+			!u.CreatedAt.Equal(want.CreatedAt) || (u.UpdatedBy == nil) != (want.UpdatedBy == nil) {
+			t.Errorf("CSV round trip failed: expected %+v, got %+v", want, u)
+		}
+	}
+}
+
+func TestFromCSV(t *testing.T) {
+	users, err := goassist.FromCSV[csvUser](strings.NewReader("name,extra,id\nAlice,x,1\nBob,y,\n"))
+	if err != nil {
+		t.Fatalf("FromCSV failed: %v", err)
+	}
+	if len(users) != 2 || users[0].ID != 1 || users[0].Name != "Alice" || users[1].ID != 0 || users[1].Name != "Bob" {
+		t.Errorf("FromCSV failed: got %+v", users)
+	}
+
+	empty, err := goassist.FromCSV[csvUser](strings.NewReader(""))
+	if err != nil || len(empty) != 0 {
+		t.Errorf("FromCSV failed: expected no rows, got %v, %v", empty, err)
+	}
+
+	_, err = goassist.FromCSV[csvUser](strings.NewReader("id,name\n1,Alice\nx,Bob\n"))
+	if err == nil || !strings.Contains(err.Error(), `line 3, column "id"`) {
+		t.Errorf("FromCSV failed: expected parse error on line 3, got %v", err)
+	}
+}
+
+// CSVMeta is exported so that a pointer to it can be embedded and allocated by FromCSV.
+type CSVMeta struct {
+	ID    int    `csv:"id"`
+	Owner string `csv:"owner"`
+}
+
+type csvMeta CSVMeta
+
+type csvDocument struct {
+	*CSVMeta
+	Title string `csv:"title"`
+}
+
+type csvPrivateDocument struct {
+	*csvMeta
+	Title string `csv:"title"`
+}
+
+func TestCSVEmbeddedPointer(t *testing.T) {
+	docs := []csvDocument{
+		{CSVMeta: &CSVMeta{ID: 1, Owner: "ann"}, Title: "plan"},
+		{Title: "draft"},
+	}
+	var sb strings.Builder
+	if err := goassist.ToCSV(&sb, docs); err != nil {
+		t.Fatalf("ToCSV failed: %v", err)
+	}
+	expected := "id,owner,title\n1,ann,plan\n,,draft\n"
+	if sb.String() != expected {
+		t.Errorf("ToCSV failed: expected\n%s\ngot\n%s", expected, sb.String())
+	}
+
+	decoded, err := goassist.FromCSV[csvDocument](strings.NewReader(sb.String()))
+	if err != nil {
+		t.Fatalf("FromCSV failed: %v", err)
+	}
+	if len(decoded) != 2 || decoded[0].CSVMeta == nil || decoded[0].ID != 1 || decoded[0].Owner != "ann" || decoded[0].Title != "plan" {
+		t.Errorf("FromCSV failed: got %+v", decoded)
+	}
+	if len(decoded) == 2 && (decoded[1].CSVMeta != nil || decoded[1].Title != "draft") {
+		t.Errorf("FromCSV failed: expected a nil embedded pointer for empty cells, got %+v", decoded[1])
+	}
+
+	// Like encoding/json, an unexported embedded struct pointer is left out.
+	sb.Reset()
+	if err := goassist.ToCSV(&sb, []csvPrivateDocument{{csvMeta: &csvMeta{ID: 1}, Title: "plan"}}); err != nil || sb.String() != "title\nplan\n" {
+		t.Errorf("ToCSV failed: expected only the title column, got %q, %v", sb.String(), err)
+	}
+}
+
+func TestCSVDuplicateColumn(t *testing.T) {
+	type duplicate struct {
+		ID    int `csv:"id"`
+		Other int `csv:"id"`
+	}
+	if err := goassist.ToCSV(&strings.Builder{}, []duplicate{{}}); !errors.Is(err, goassist.ErrCSVType) {
+		t.Errorf("ToCSV failed: expected ErrCSVType for a duplicate column, got %v", err)
+	}
+	type shadowed struct {
+		CSVMeta
+		ID int `csv:"id"`
+	}
+	if _, err := goassist.FromCSV[shadowed](strings.NewReader("id\n1\n")); err == nil || !strings.Contains(err.Error(), `duplicate column "id"`) {
+		t.Errorf("FromCSV failed: expected duplicate column error, got %v", err)
+	}
+}
+
+func TestCSVUnsupportedType(t *testing.T) {
+	type withSlice struct {
+		Tags []string
+	}
+	if err := goassist.ToCSV(&strings.Builder{}, []withSlice{{}}); !errors.Is(err, goassist.ErrCSVType) {
+		t.Errorf("ToCSV failed: expected ErrCSVType, got %v", err)
+	}
+	if _, err := goassist.FromCSV[int](strings.NewReader("1\n")); !errors.Is(err, goassist.ErrCSVType) {
+		t.Errorf("FromCSV failed: expected ErrCSVType, got %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+package goassist_test
+
+import (
+	"bytes"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+// Fuzz targets run their seed corpus as part of go test. Run one with random inputs using, e.g.:
+//
+//	go test ./test -run '^$' -fuzz '^FuzzZip$' -fuzztime 30s
+
+func FuzzZip(f *testing.F) {
+	f.Add([]byte{1, 2, 3}, "ab")
+	f.Add([]byte{}, "abc")
+	f.Fuzz(func(t *testing.T, a []byte, b string) {
+		pairs := goassist.Zip(a, []byte(b))
+		if len(pairs) != min(len(a), len(b)) {
+			t.Fatalf("Zip: expected %d pairs, got %d", min(len(a), len(b)), len(pairs))
+		}
+		first, second := goassist.Unzip[byte, byte](pairs)
+		if !bytes.Equal(first, a[:len(pairs)]) || string(second) != b[:len(pairs)] {
+			t.Fatalf("Unzip(Zip(%v, %q)) returned %v, %v", a, b, first, second)
+		}
+	})
+}
+
+func FuzzSlice(f *testing.F) {
+	f.Add([]byte("hello"), -2, 5)
+	f.Add([]byte("hello"), 3, 1)
+	f.Add([]byte{}, 0, 10)
+	f.Fuzz(func(t *testing.T, data []byte, start, end int) {
+		got := goassist.Slice(data, start, end)
+		if len(got) > len(data) || !bytes.Contains(data, got) {
+			t.Fatalf("Slice(%q, %d, %d) returned %q", data, start, end, got)
+		}
+		if start >= 0 && end >= start && end <= len(data) && !bytes.Equal(got, data[start:end]) {
+			t.Fatalf("Slice(%q, %d, %d) returned %q, expected %q", data, start, end, got, data[start:end])
+		}
+	})
+}
+
+func FuzzSortedCompact(f *testing.F) {
+	f.Add([]byte("mississippi"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		unique := goassist.Compact(goassist.Sorted(data))
+		for i, b := range unique {
+			if i > 0 && unique[i-1] >= b {
+				t.Fatalf("Compact(Sorted(%v)) is not strictly increasing: %v", data, unique)
+			}
+			if !goassist.Contains(data, b) {
+				t.Fatalf("Compact(Sorted(%v)) contains %d, which is not in the input", data, b)
+			}
+		}
+	})
+}
+
+func FuzzEditDistance(f *testing.F) {
+	f.Add("kitten", "sitting")
+	f.Add("ca", "abc")
+	f.Add("héllo", "")
+	f.Fuzz(func(t *testing.T, a, b string) {
+		d := goassist.LevenshteinDistance(a, b)
+		if d != goassist.LevenshteinDistance(b, a) {
+			t.Fatalf("LevenshteinDistance(%q, %q) is not symmetric", a, b)
+		}
+		if (d == 0) != (a == b) && utf8.ValidString(a) && utf8.ValidString(b) {
+			t.Fatalf("LevenshteinDistance(%q, %q) = %d", a, b, d)
+		}
+		if d > max(utf8.RuneCountInString(a), utf8.RuneCountInString(b)) {
+			t.Fatalf("LevenshteinDistance(%q, %q) = %d exceeds the longer length", a, b, d)
+		}
+		if dl := goassist.DamerauLevenshteinDistance(a, b); dl > d {
+			t.Fatalf("DamerauLevenshteinDistance(%q, %q) = %d exceeds LevenshteinDistance %d", a, b, dl, d)
+		}
+		for _, s := range []float64{goassist.JaroWinklerSimilarity(a, b), goassist.TrigramSimilarity(a, b)} {
+			if math.IsNaN(s) || s < 0 || s > 1 {
+				t.Fatalf("similarity of %q and %q is %v, expected a value in [0, 1]", a, b, s)
+			}
+		}
+	})
+}
+
+func FuzzFromCSV(f *testing.F) {
+	f.Add("id,name,score,Active\n1,Ann,9.5,true\n")
+	f.Add("id,name\n\"unterminated\n")
+	f.Add("score\nnot-a-number\n")
+	f.Fuzz(func(t *testing.T, data string) {
+		users, err := goassist.FromCSV[csvUser](strings.NewReader(data))
+		if err != nil {
+			return
+		}
+		var buf bytes.Buffer
+		if err := goassist.ToCSV(&buf, users); err != nil {
+			t.Fatalf("ToCSV failed on rows decoded from %q: %v", data, err)
+		}
+	})
+}
+
+func FuzzDecodeJSONSeq(f *testing.F) {
+	f.Add(`[{"id":1,"amount":5},{"id":2,"amount":7}]`)
+	f.Add(`{"id":1}` + "\n" + `{"id":2}`)
+	f.Add(`[{"id":1},`)
+	f.Fuzz(func(t *testing.T, data string) {
+		for _, err := range goassist.DecodeJSONSeq[order](strings.NewReader(data)) {
+			if err != nil {
+				break
+			}
+		}
+	})
+}
+
+func FuzzLines(f *testing.F) {
+	f.Add("a\r\nb\nc")
+	f.Add("\r\r\n\n")
+	f.Fuzz(func(t *testing.T, data string) {
+		var lines []string
+		for line, err := range goassist.Lines(strings.NewReader(data)) {
+			if err != nil {
+				t.Fatalf("Lines(%q) failed: %v", data, err)
+			}
+			lines = append(lines, line)
+		}
+		joined := strings.Join(lines, "")
+		if strings.Contains(joined, "\n") || len(joined) > len(data) {
+			t.Fatalf("Lines(%q) returned %q", data, lines)
+		}
+	})
+}
+
+func FuzzTextIndexSearch(f *testing.F) {
+	docs := []string{"login fails on mobile", "password reset email", "logout button missing", "rebuild the index"}
+	index := goassist.NewTextIndex(docs, strings.Clone, goassist.TextIndexOptions{})
+	f.Add("login")
+	f.Add("in")
+	f.Add("the")
+	f.Add("log* -mobile OR password")
+	f.Add("NOT OR * -")
+	f.Fuzz(func(t *testing.T, query string) {
+		for _, hit := range index.SearchHits(query) {
+			if hit.Index < 0 || hit.Index >= len(docs) || docs[hit.Index] != hit.Value {
+				t.Fatalf("Search(%q) returned invalid hit %+v", query, hit)
+			}
+		}
+
+		// For a single word, compare with a naive scan of the documents' indexed words.
+		words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if len(words) != 1 {
+			return
+		}
+		w := words[0]
+		var exact, prefixed []string
+		for _, doc := range docs {
+			docWords := goassist.Filter(strings.Fields(doc), func(dw string) bool {
+				return !slices.Contains(goassist.DefaultStopWords, dw)
+			})
+			if slices.Contains(docWords, w) && !slices.Contains(goassist.DefaultStopWords, w) {
+				exact = append(exact, doc)
+			}
+			if slices.ContainsFunc(docWords, func(dw string) bool { return strings.HasPrefix(dw, w) }) {
+				prefixed = append(prefixed, doc)
+			}
+		}
+		if got := goassist.Sorted(index.Search(w)); !slices.Equal(got, goassist.Sorted(exact)) {
+			t.Fatalf("Search(%q) returned %q, expected %q", w, got, exact)
+		}
+		if got := goassist.Sorted(index.Search(w + "*")); !slices.Equal(got, goassist.Sorted(prefixed)) {
+			t.Fatalf("Search(%q) returned %q, expected %q", w+"*", got, prefixed)
+		}
+	})
+}
+
+func FuzzGridRotate(f *testing.F) {
+	f.Add([]byte("abcdef"), uint8(3))
+	f.Add([]byte("x"), uint8(1))
+	f.Fuzz(func(t *testing.T, data []byte, cols uint8) {
+		if cols == 0 || len(data)%int(cols) != 0 {
+			return
+		}
+		g, err := goassist.GridOf(slices.Collect(slices.Chunk(data, int(cols))))
+		if err != nil {
+			t.Fatalf("GridOf failed: %v", err)
+		}
+		if got := g.Rotate(1).Rotate(1).Rotate(1).Rotate(1); !bytes.Equal(got.Values(), data) {
+			t.Fatalf("four quarter turns of %v returned %v", g, got)
+		}
+		if got := g.Transpose().Transpose(); !bytes.Equal(got.Values(), data) || got.Cols() != g.Cols() {
+			t.Fatalf("Transpose twice of %v returned %v", g, got)
+		}
+		if got := g.Rotate(2); !bytes.Equal(got.Values(), goassist.Reversed(data)) {
+			t.Fatalf("Rotate(2) of %v returned %v", g, got)
+		}
+	})
+}
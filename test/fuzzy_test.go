@@ -0,0 +1,127 @@
+package goassist_test
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+func TestLevenshteinDistance(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		lev, dam int
+	}{
+		{"kitten", "sitting", 3, 3},
+		{"form", "from", 2, 1},
+		{"", "abc", 3, 3},
+		{"ca", "abc", 3, 3},
+		{"привет", "првиет", 2, 1},
+	}
+	for _, c := range cases {
+		if d := goassist.LevenshteinDistance(c.a, c.b); d != c.lev {
+			t.Errorf("LevenshteinDistance(%q, %q) failed: expected %d, got %d", c.a, c.b, c.lev, d)
+		}
+		if d := goassist.DamerauLevenshteinDistance(c.a, c.b); d != c.dam {
+			t.Errorf("DamerauLevenshteinDistance(%q, %q) failed: expected %d, got %d", c.a, c.b, c.dam, d)
+		}
+	}
+}
+
+func TestJaroWinklerSimilarity(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected float64
+	}{
+		{"martha", "marhta", 0.9611},
+		{"dixon", "dicksonx", 0.8133},
+		{"abc", "xyz", 0},
+		{"same", "same", 1},
+	}
+	for _, c := range cases {
+		if s := goassist.JaroWinklerSimilarity(c.a, c.b); math.Abs(s-c.expected) > 0.0001 {
+			t.Errorf("JaroWinklerSimilarity(%q, %q) failed: expected %.4f, got %.4f", c.a, c.b, c.expected, s)
+		}
+	}
+}
+
+func TestTrigramSimilarity(t *testing.T) {
+	if s := goassist.TrigramSimilarity("hello world", "world hello"); s < 0.7 || s > 0.72 {
+		t.Errorf("TrigramSimilarity failed: expected about 0.71, got %v", s)
+	}
+	if s := goassist.TrigramSimilarity("abc", "xyz"); s != 0 {
+		t.Errorf("TrigramSimilarity failed: expected 0, got %v", s)
+	}
+}
+
+func TestRankByDistance(t *testing.T) {
+	ranked := goassist.RankByDistance([]string{"commit", "stash", "start", "status"}, "stats", goassist.MetricDamerau)
+	values := goassist.Map(ranked, func(m goassist.FuzzyMatch) string { return m.Value })
+	if expected := []string{"status", "stash", "start", "commit"}; !slices.Equal(values, expected) {
+		t.Errorf("RankByDistance failed: expected %v, got %v", expected, values)
+	}
+	if ranked[0].Index != 3 {
+		t.Errorf("RankByDistance failed: expected index 3, got %d", ranked[0].Index)
+	}
+}
+
+func TestFuzzyFind(t *testing.T) {
+	names := []string{"Johnson", "Jonson", "Jameson", "Smith"}
+	matches := goassist.FuzzyFind(names, "Jonsen", goassist.FuzzyOptions{
+		Metric:    goassist.MetricJaroWinkler,
+		Threshold: 0.85,
+		Limit:     2,
+	})
+	values := goassist.Map(matches, func(m goassist.FuzzyMatch) string { return m.Value })
+	if expected := []string{"Jonson", "Johnson"}; !slices.Equal(values, expected) {
+		t.Errorf("FuzzyFind failed: expected %v, got %v", expected, values)
+	}
+
+	none := goassist.FuzzyFind(names, "Williams", goassist.FuzzyOptions{Metric: goassist.MetricTrigram, Threshold: 0.5})
+	if len(none) != 0 {
+		t.Errorf("FuzzyFind failed: expected no matches, got %v", none)
+	}
+}
+
+func TestNGramIndex(t *testing.T) {
+	products := make([]string, 0, 1000)
+	for i := range 1000 {
+		products = append(products, fmt.Sprintf("product %d", i))
+	}
+	products = append(products, "iphone", "iphone case", "ipad", "android")
+	index := goassist.NewNGramIndex(products, 3)
+
+	opts := goassist.FuzzyOptions{Metric: goassist.MetricDamerau, Threshold: 0.6, Limit: 5}
+	matches := index.Search("iphnoe", opts)
+	if len(matches) != 1 || matches[0].Value != "iphone" || matches[0].Index != 1000 {
+		t.Errorf("NGramIndex failed: expected iphone at 1000, got %v", matches)
+	}
+
+	for _, query := range []string{"product 42", "ipda", "andriod"} {
+		expected := goassist.FuzzyFind(products, query, opts)
+		got := index.Search(query, opts)
+		if fmt.Sprint(got) != fmt.Sprint(expected) {
+			t.Errorf("NGramIndex failed: expected %v, got %v for %q", expected, got, query)
+		}
+	}
+}
+
+func BenchmarkFuzzyFind(b *testing.B) {
+	words := benchmarkWords()
+	opts := goassist.FuzzyOptions{Metric: goassist.MetricLevenshtein, Threshold: 0.7, Limit: 10}
+	b.ResetTimer()
+	for range b.N {
+		goassist.FuzzyFind(words, "km01234", opts)
+	}
+}
+
+func BenchmarkNGramIndexSearch(b *testing.B) {
+	index := goassist.NewNGramIndex(benchmarkWords(), 3)
+	opts := goassist.FuzzyOptions{Metric: goassist.MetricLevenshtein, Threshold: 0.7, Limit: 10}
+	b.ResetTimer()
+	for range b.N {
+		index.Search("km01234", opts)
+	}
+}
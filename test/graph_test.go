@@ -0,0 +1,122 @@
+package goassist_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+type edge = goassist.Edge[string]
+
+func TestTopologicalSort(t *testing.T) {
+	g := goassist.NewGraph([]edge{
+		{From: "compile", To: "link"},
+		{From: "generate", To: "compile"},
+		{From: "assets", To: "link"},
+	})
+	g.AddNode("docs")
+	order, err := goassist.TopologicalSort(g)
+	expected := []string{"assets", "docs", "generate", "compile", "link"}
+	if err != nil || !slices.Equal(order, expected) {
+		t.Errorf("TopologicalSort failed: expected %v, got %v, %v", expected, order, err)
+	}
+
+	reversed, err := g.TopologicalSortFunc(func(a, b string) int {
+		return -goassist.Compare([]byte(a), []byte(b))
+	})
+	expected = []string{"generate", "docs", "compile", "assets", "link"}
+	if err != nil || !slices.Equal(reversed, expected) {
+		t.Errorf("TopologicalSortFunc failed: expected %v, got %v, %v", expected, reversed, err)
+	}
+}
+
+func TestTopologicalSortCycle(t *testing.T) {
+	g := goassist.NewGraph([]edge{
+		{From: "init", To: "a"},
+		{From: "a", To: "b"},
+		{From: "b", To: "c"},
+		{From: "c", To: "a"},
+	})
+	_, err := goassist.TopologicalSort(g)
+	var cycleErr *goassist.CycleError[string]
+	if !errors.As(err, &cycleErr) || !errors.Is(err, goassist.ErrCycle) {
+		t.Fatalf("TopologicalSort failed: expected CycleError, got %v", err)
+	}
+	if expected := []string{"a", "b", "c", "a"}; !slices.Equal(cycleErr.Path, expected) {
+		t.Errorf("TopologicalSort failed: expected cycle %v, got %v", expected, cycleErr.Path)
+	}
+
+	undirected := goassist.NewUndirectedGraph([]edge{{From: "a", To: "b"}})
+	if _, err := goassist.TopologicalSort(undirected); !errors.Is(err, goassist.ErrUndirectedGraph) {
+		t.Errorf("TopologicalSort failed: expected ErrUndirectedGraph, got %v", err)
+	}
+}
+
+func TestFindCycle(t *testing.T) {
+	dag := goassist.NewGraph([]edge{{From: "a", To: "b"}, {From: "a", To: "c"}, {From: "b", To: "c"}})
+	if cycle, found := dag.FindCycle(); found {
+		t.Errorf("FindCycle failed: expected no cycle, got %v", cycle)
+	}
+
+	tree := goassist.NewUndirectedGraph([]edge{{From: "a", To: "b"}, {From: "b", To: "c"}})
+	if cycle, found := tree.FindCycle(); found {
+		t.Errorf("FindCycle failed: expected no cycle in undirected tree, got %v", cycle)
+	}
+	tree.AddEdge("c", "a", 0)
+	cycle, found := tree.FindCycle()
+	if !found || len(cycle) != 4 || cycle[0] != cycle[3] {
+		t.Errorf("FindCycle failed: expected triangle, got %v", cycle)
+	}
+}
+
+func TestGraphTraversal(t *testing.T) {
+	g := goassist.NewGraph([]goassist.Edge[int]{{From: 1, To: 2}, {From: 1, To: 3}, {From: 2, To: 4}, {From: 3, To: 4}})
+	bfs := slices.Collect(g.BFS(1))
+	if !slices.Equal(bfs, []int{1, 2, 3, 4}) {
+		t.Errorf("BFS failed: expected [1 2 3 4], got %v", bfs)
+	}
+	dfs := slices.Collect(g.DFS(1))
+	if !slices.Equal(dfs, []int{1, 2, 4, 3}) {
+		t.Errorf("DFS failed: expected [1 2 4 3], got %v", dfs)
+	}
+	if fromLeaf := slices.Collect(g.BFS(4)); !slices.Equal(fromLeaf, []int{4}) {
+		t.Errorf("BFS failed: expected [4], got %v", fromLeaf)
+	}
+	if neighbors := g.Neighbors(1); !slices.Equal(neighbors, []int{2, 3}) {
+		t.Errorf("Neighbors failed: expected [2 3], got %v", neighbors)
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	g := goassist.NewUndirectedGraph([]edge{
+		{From: "home", To: "park", Weight: 4},
+		{From: "home", To: "shop", Weight: 1},
+		{From: "shop", To: "park", Weight: 2},
+		{From: "park", To: "lake", Weight: 5},
+	})
+	g.AddNode("island")
+	path, dist, found := g.ShortestPath("home", "lake")
+	if !found || dist != 8 || !slices.Equal(path, []string{"home", "shop", "park", "lake"}) {
+		t.Errorf("ShortestPath failed: expected home-shop-park-lake (8), got %v (%v), %v", path, dist, found)
+	}
+	if _, _, found := g.ShortestPath("home", "island"); found {
+		t.Error("ShortestPath failed: expected island to be unreachable")
+	}
+}
+
+func TestComponents(t *testing.T) {
+	g := goassist.NewGraph([]goassist.Edge[int]{{From: 1, To: 2}, {From: 3, To: 4}, {From: 5, To: 2}})
+	g.AddNode(6)
+	groups := g.Components()
+	expected := [][]int{{1, 2, 5}, {3, 4}, {6}}
+	if len(groups) != len(expected) {
+		t.Fatalf("Components failed: expected %v, got %v", expected, groups)
+	}
+	for i := range groups {
+		if !slices.Equal(groups[i], expected[i]) {
+			t.Errorf("Components failed: expected %v, got %v", expected[i], groups[i])
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package goassist_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+func mustGrid[T any](t *testing.T, rows [][]T) *goassist.Grid[T] {
+	t.Helper()
+	g, err := goassist.GridOf(rows)
+	if err != nil {
+		t.Fatalf("GridOf failed: %v", err)
+	}
+	return g
+}
+
+// cells builds a []goassist.Cell from row, column pairs.
+func cells(coords ...int) []goassist.Cell {
+	result := make([]goassist.Cell, 0, len(coords)/2)
+	for i := 0; i+1 < len(coords); i += 2 {
+		result = append(result, goassist.Cell{Row: coords[i], Col: coords[i+1]})
+	}
+	return result
+}
+
+func TestGrid(t *testing.T) {
+	board := goassist.NewGrid[rune](3, 3)
+	board.Set(1, 1, 'X')
+	board.Set(0, 2, 'O')
+	if board.Get(1, 1) != 'X' || board.Get(0, 2) != 'O' || board.Get(2, 2) != 0 {
+		t.Errorf("Grid.Get failed: got %v", board)
+	}
+	if board.InBounds(3, 0) || board.InBounds(0, -1) || !board.InBounds(2, 2) {
+		t.Error("Grid.InBounds failed")
+	}
+	if got := board.Values(); len(got) != 9 || got[5] != 0 || got[4] != 'X' {
+		t.Errorf("Grid.Values failed: got %v", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Grid.Get failed: expected panic for cell out of range")
+		}
+	}()
+	board.Get(0, 3)
+}
+
+func TestGridOf(t *testing.T) {
+	rows := [][]int{{1, 2, 3}, {4, 5, 6}}
+	g := mustGrid(t, rows)
+	if g.Rows() != 2 || g.Cols() != 3 || g.String() != "[[1 2 3] [4 5 6]]" {
+		t.Errorf("GridOf failed: got %dx%d %v", g.Rows(), g.Cols(), g)
+	}
+	rows[0][0] = 100
+	if g.Get(0, 0) != 1 {
+		t.Error("GridOf failed: expected a copy of the rows")
+	}
+	back := g.ToSlices()
+	back[1][1] = 100
+	if g.Get(1, 1) != 5 {
+		t.Error("Grid.ToSlices failed: expected a copy of the cells")
+	}
+
+	if _, err := goassist.GridOf([][]int{{1, 2}, {3}}); !errors.Is(err, goassist.ErrRaggedRows) {
+		t.Errorf("GridOf failed: expected ErrRaggedRows, got %v", err)
+	}
+	if empty := mustGrid[int](t, nil); empty.Rows() != 0 || empty.Cols() != 0 || len(empty.ToSlices()) != 0 {
+		t.Errorf("GridOf failed: expected empty grid, got %v", empty)
+	}
+}
+
+func TestGridRowCol(t *testing.T) {
+	g := mustGrid(t, [][]int{{1, 2, 3}, {4, 5, 6}})
+	var row, col []int
+	for c, v := range g.Row(1) {
+		row = append(row, c*10+v)
+	}
+	for r, v := range g.Col(2) {
+		col = append(col, r*10+v)
+	}
+	if !slices.Equal(row, []int{4, 15, 26}) || !slices.Equal(col, []int{3, 16}) {
+		t.Errorf("Grid.Row/Col failed: got %v and %v", row, col)
+	}
+
+	var visited []goassist.Cell
+	for c, v := range g.All() {
+		if v == 5 {
+			break
+		}
+		visited = append(visited, c)
+	}
+	if !slices.Equal(visited, cells(0, 0, 0, 1, 0, 2, 1, 0)) {
+		t.Errorf("Grid.All failed: got %v", visited)
+	}
+}
+
+func TestGridNeighbors(t *testing.T) {
+	g := goassist.NewGrid[int](3, 3)
+	if got := slices.Collect(g.Neighbors(0, 0, goassist.FourConnected)); !slices.Equal(got, cells(0, 1, 1, 0)) {
+		t.Errorf("Grid.Neighbors failed: got %v", got)
+	}
+	if got := slices.Collect(g.Neighbors(1, 1, goassist.FourConnected)); !slices.Equal(got, cells(0, 1, 1, 0, 1, 2, 2, 1)) {
+		t.Errorf("Grid.Neighbors failed: got %v", got)
+	}
+	if got := slices.Collect(g.Neighbors(2, 2, goassist.EightConnected)); !slices.Equal(got, cells(1, 1, 1, 2, 2, 1)) {
+		t.Errorf("Grid.Neighbors failed: got %v", got)
+	}
+	if got := slices.Collect(g.Neighbors(1, 1, goassist.EightConnected)); len(got) != 8 {
+		t.Errorf("Grid.Neighbors failed: expected 8 neighbors, got %v", got)
+	}
+}
+
+func TestGridRegion(t *testing.T) {
+	g := mustGrid(t, [][]byte{
+		[]byte("##."),
+		[]byte("#.."),
+		[]byte("..#"),
+	})
+	isWall := func(b byte) bool { return b == '#' }
+	if got := g.Region(0, 0, goassist.FourConnected, isWall); !slices.Equal(got, cells(0, 0, 0, 1, 1, 0)) {
+		t.Errorf("Grid.Region failed: got %v", got)
+	}
+	if got := g.Region(2, 2, goassist.FourConnected, isWall); len(got) != 1 {
+		t.Errorf("Grid.Region failed: expected isolated cell, got %v", got)
+	}
+	if got := g.Region(0, 2, goassist.FourConnected, isWall); got != nil {
+		t.Errorf("Grid.Region failed: expected nil for non-matching start, got %v", got)
+	}
+	if got := g.Region(5, 5, goassist.FourConnected, isWall); got != nil {
+		t.Errorf("Grid.Region failed: expected nil for start out of range, got %v", got)
+	}
+
+	isFloor := func(b byte) bool { return b == '.' }
+	if got := g.Region(0, 2, goassist.FourConnected, isFloor); !slices.Equal(got, cells(0, 2, 1, 2, 1, 1, 2, 1, 2, 0)) {
+		t.Errorf("Grid.Region failed: got %v", got)
+	}
+
+	diagonal := mustGrid(t, [][]byte{[]byte("#."), []byte(".#")})
+	if got := diagonal.Region(0, 0, goassist.FourConnected, isWall); len(got) != 1 {
+		t.Errorf("Grid.Region failed: expected 1 cell without diagonals, got %v", got)
+	}
+	if got := diagonal.Region(0, 0, goassist.EightConnected, isWall); !slices.Equal(got, cells(0, 0, 1, 1)) {
+		t.Errorf("Grid.Region failed: expected 2 cells with diagonals, got %v", got)
+	}
+}
+
+func TestGridFloodFill(t *testing.T) {
+	image := mustGrid(t, [][]int{
+		{0, 0, 1},
+		{0, 1, 1},
+		{1, 0, 0},
+	})
+	filled := image.FloodFill(0, 0, goassist.FourConnected, 7, func(v int) bool { return v == 0 })
+	if filled != 3 || image.String() != "[[7 7 1] [7 1 1] [1 0 0]]" {
+		t.Errorf("Grid.FloodFill failed: filled %d, got %v", filled, image)
+	}
+
+	// A fill value that still matches must not loop forever.
+	filled = image.FloodFill(0, 2, goassist.EightConnected, 2, func(v int) bool { return v > 0 })
+	if filled != 7 || image.String() != "[[2 2 2] [2 2 2] [2 0 0]]" {
+		t.Errorf("Grid.FloodFill failed: filled %d, got %v", filled, image)
+	}
+}
+
+func TestGridTransposeRotate(t *testing.T) {
+	g := mustGrid(t, [][]int{{1, 2, 3}, {4, 5, 6}})
+	tests := []struct {
+		name string
+		got  *goassist.Grid[int]
+		want string
+	}{
+		{"Transpose", g.Transpose(), "[[1 4] [2 5] [3 6]]"},
+		{"Rotate(0)", g.Rotate(0), "[[1 2 3] [4 5 6]]"},
+		{"Rotate(1)", g.Rotate(1), "[[4 1] [5 2] [6 3]]"},
+		{"Rotate(2)", g.Rotate(2), "[[6 5 4] [3 2 1]]"},
+		{"Rotate(3)", g.Rotate(3), "[[3 6] [2 5] [1 4]]"},
+		{"Rotate(-1)", g.Rotate(-1), "[[3 6] [2 5] [1 4]]"},
+		{"Rotate(5)", g.Rotate(5), "[[4 1] [5 2] [6 3]]"},
+	}
+	for _, tt := range tests {
+		if tt.got.String() != tt.want {
+			t.Errorf("Grid.%s failed: expected %s, got %v", tt.name, tt.want, tt.got)
+		}
+	}
+	if g.String() != "[[1 2 3] [4 5 6]]" {
+		t.Errorf("Grid.Rotate failed: modified the original grid, got %v", g)
+	}
+	if back := g.Rotate(1).Rotate(-1); back.String() != g.String() {
+		t.Errorf("Grid.Rotate failed: expected rotation to be reversible, got %v", back)
+	}
+}
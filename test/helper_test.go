@@ -1,6 +1,8 @@
 package goassist_test
 
 import (
+	"errors"
+	"slices"
 	"strings"
 	"testing"
 
@@ -85,6 +87,86 @@ func TestFlatten(t *testing.T) {
 	}
 }
 
+func TestFlatten3(t *testing.T) {
+	cube := [][][]int{{{1, 2}, {3}}, {{4}, {5, 6}}}
+	flat := goassist.Flatten3(cube)
+	expected := []int{1, 2, 3, 4, 5, 6}
+	if len(flat) != len(expected) {
+		t.Fatalf("Flatten3 failed: expected %v, got %v", expected, flat)
+	}
+	for i, v := range flat {
+		if v != expected[i] {
+			t.Errorf("Flatten3 failed: expected %d, got %d", expected[i], v)
+		}
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	sentences := []string{"hello world", "go assist"}
+	words := goassist.FlatMap(sentences, strings.Fields)
+	expected := []string{"hello", "world", "go", "assist"}
+	if len(words) != len(expected) {
+		t.Fatalf("FlatMap failed: expected %v, got %v", expected, words)
+	}
+	for i, v := range words {
+		if v != expected[i] {
+			t.Errorf("FlatMap failed: expected %s, got %s", expected[i], v)
+		}
+	}
+}
+
+func TestFlattenSeq(t *testing.T) {
+	nested := [][]int{{1, 2}, {}, {3}}
+	seq := goassist.MapSeq(slices.Values(nested), slices.Values[[]int])
+	flat := slices.Collect(goassist.FlattenSeq(seq))
+	expected := []int{1, 2, 3}
+	if !slices.Equal(flat, expected) {
+		t.Errorf("FlattenSeq failed: expected %v, got %v", expected, flat)
+	}
+}
+
+func TestDeepFlatten(t *testing.T) {
+	nested := []any{1, []int{2, 3}, [][]int{{4}, {5, 6}}, [2]int{7, 8}, []any{[]any{9}}}
+	flat, err := goassist.DeepFlatten[int](nested)
+	expected := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if err != nil || !slices.Equal(flat, expected) {
+		t.Errorf("DeepFlatten failed: expected %v, got %v, %v", expected, flat, err)
+	}
+
+	pairs, err := goassist.DeepFlatten[[]int]([][][]int{{{1, 2}}, {{3}, {4}}})
+	if err != nil || len(pairs) != 3 || !slices.Equal(pairs[0], []int{1, 2}) {
+		t.Errorf("DeepFlatten failed: expected [[1 2] [3] [4]], got %v, %v", pairs, err)
+	}
+
+	anything, err := goassist.DeepFlatten[any]([]any{1, []int{2, 3}, "four", [1][]string{{"five"}}})
+	if err != nil || !slices.Equal(anything, []any{1, 2, 3, "four", "five"}) {
+		t.Errorf("DeepFlatten failed: expected [1 2 3 four five], got %v, %v", anything, err)
+	}
+
+	withNil, err := goassist.DeepFlatten[any]([]any{1, nil, []any{nil}})
+	if err != nil || !slices.Equal(withNil, []any{1, nil, nil}) {
+		t.Errorf("DeepFlatten failed: expected [1 <nil> <nil>], got %v, %v", withNil, err)
+	}
+	errs, err := goassist.DeepFlatten[error]([]any{nil})
+	if err != nil || len(errs) != 1 || errs[0] != nil {
+		t.Errorf("DeepFlatten failed: expected [<nil>], got %v, %v", errs, err)
+	}
+	for _, input := range []any{[]any{1, nil}, []any{[]any{nil}}, nil} {
+		_, err = goassist.DeepFlatten[int](input)
+		if !errors.Is(err, goassist.ErrDeepFlattenType) || !strings.Contains(err.Error(), "cannot use nil as int") {
+			t.Errorf("DeepFlatten failed: expected nil error for %v, got %v", input, err)
+		}
+	}
+
+	_, err = goassist.DeepFlatten[int]([]any{1, []any{2, "three"}})
+	if !errors.Is(err, goassist.ErrDeepFlattenType) {
+		t.Errorf("DeepFlatten failed: expected ErrDeepFlattenType, got %v", err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "[1][1]") {
+		t.Errorf("DeepFlatten failed: expected error to name the path, got %v", err)
+	}
+}
+
 func TestZip(t *testing.T) {
 	numbers := []int{1, 2, 3}
 	letters := []string{"a", "b", "c"}
@@ -464,3 +546,228 @@ func TestSortStableFunc(t *testing.T) {
 		}
 	}
 }
+
+func assertUnchanged[E comparable](t *testing.T, name string, got, original []E) {
+	t.Helper()
+	if !slices.Equal(got, original) {
+		t.Errorf("%s failed: input was modified to %v, expected %v", name, got, original)
+	}
+}
+
+func TestSorted(t *testing.T) {
+	numbers := []int{3, 1, 4, 1, 5, 9}
+	original := slices.Clone(numbers)
+	sorted := goassist.Sorted(numbers)
+	if expected := []int{1, 1, 3, 4, 5, 9}; !slices.Equal(sorted, expected) {
+		t.Errorf("Sorted failed: expected %v, got %v", expected, sorted)
+	}
+	assertUnchanged(t, "Sorted", numbers, original)
+
+	words := []string{"pear", "fig", "apple"}
+	byLength := goassist.SortedFunc(words, func(a, b string) int { return len(a) - len(b) })
+	if expected := []string{"fig", "pear", "apple"}; !slices.Equal(byLength, expected) {
+		t.Errorf("SortedFunc failed: expected %v, got %v", expected, byLength)
+	}
+	assertUnchanged(t, "SortedFunc", words, []string{"pear", "fig", "apple"})
+}
+
+func TestReversed(t *testing.T) {
+	numbers := []int{1, 2, 3}
+	reversed := goassist.Reversed(numbers)
+	if expected := []int{3, 2, 1}; !slices.Equal(reversed, expected) {
+		t.Errorf("Reversed failed: expected %v, got %v", expected, reversed)
+	}
+	assertUnchanged(t, "Reversed", numbers, []int{1, 2, 3})
+}
+
+func TestWithDeletedInserted(t *testing.T) {
+	numbers := []int{1, 2, 3, 4, 5}
+	rest := goassist.WithDeleted(numbers, 1, 3)
+	if expected := []int{1, 4, 5}; !slices.Equal(rest, expected) {
+		t.Errorf("WithDeleted failed: expected %v, got %v", expected, rest)
+	}
+	assertUnchanged(t, "WithDeleted", numbers, []int{1, 2, 3, 4, 5})
+
+	// Spare capacity must not let WithInserted write into the caller's backing array.
+	base := make([]int, 3, 10)
+	copy(base, []int{1, 2, 5})
+	all := goassist.WithInserted(base, 2, 3, 4)
+	if expected := []int{1, 2, 3, 4, 5}; !slices.Equal(all, expected) {
+		t.Errorf("WithInserted failed: expected %v, got %v", expected, all)
+	}
+	assertUnchanged(t, "WithInserted", base[:5], []int{1, 2, 5, 0, 0})
+}
+
+func TestCompacted(t *testing.T) {
+	numbers := []int{1, 1, 2, 3, 3, 3, 4}
+	unique := goassist.Compacted(numbers)
+	if expected := []int{1, 2, 3, 4}; !slices.Equal(unique, expected) {
+		t.Errorf("Compacted failed: expected %v, got %v", expected, unique)
+	}
+	assertUnchanged(t, "Compacted", numbers, []int{1, 1, 2, 3, 3, 3, 4})
+}
+
+func TestMapInto(t *testing.T) {
+	buf := make([]int, 0, 8)
+	buf = goassist.MapInto(buf, []string{"a", "bb"}, func(s string) int { return len(s) })
+	buf = goassist.MapInto(buf, []string{"ccc"}, func(s string) int { return len(s) })
+	if expected := []int{1, 2, 3}; !slices.Equal(buf, expected) {
+		t.Errorf("MapInto failed: expected %v, got %v", expected, buf)
+	}
+
+	src := []int{1, 2, 3, 4}
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = goassist.MapInto(buf[:0], src, func(x int) int { return x * 2 })
+	})
+	if allocs != 0 {
+		t.Errorf("MapInto failed: expected no allocations, got %v", allocs)
+	}
+	if grown := goassist.MapInto(nil, src, func(x int) int { return x }); !slices.Equal(grown, src) {
+		t.Errorf("MapInto failed: expected %v, got %v", src, grown)
+	}
+}
+
+func TestFilterInto(t *testing.T) {
+	even := func(x int) bool { return x%2 == 0 }
+	buf := goassist.FilterInto([]int{0}, []int{1, 2, 3, 4}, even)
+	if expected := []int{0, 2, 4}; !slices.Equal(buf, expected) {
+		t.Errorf("FilterInto failed: expected %v, got %v", expected, buf)
+	}
+
+	src := []int{1, 2, 3, 4, 5, 6}
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = goassist.FilterInto(buf[:0], src, even)
+	})
+	if allocs != 0 {
+		t.Errorf("FilterInto failed: expected no allocations, got %v", allocs)
+	}
+}
+
+func TestFilterInPlace(t *testing.T) {
+	numbers := []int{1, 2, 3, 4, 5}
+	backing := numbers
+	numbers = goassist.FilterInPlace(numbers, func(x int) bool { return x%2 == 0 })
+	if expected := []int{2, 4}; !slices.Equal(numbers, expected) {
+		t.Errorf("FilterInPlace failed: expected %v, got %v", expected, numbers)
+	}
+	if &numbers[0] != &backing[0] || !slices.Equal(backing, []int{2, 4, 0, 0, 0}) {
+		t.Errorf("FilterInPlace failed: expected the backing array to be reused and cleared, got %v", backing)
+	}
+
+	data := make([]int, 100)
+	allocs := testing.AllocsPerRun(100, func() {
+		goassist.FilterInPlace(data, func(x int) bool { return x == 0 })
+	})
+	if allocs != 0 {
+		t.Errorf("FilterInPlace failed: expected no allocations, got %v", allocs)
+	}
+}
+
+func BenchmarkMap(b *testing.B) {
+	src := make([]int, 1000)
+	b.ReportAllocs()
+	for range b.N {
+		goassist.Map(src, func(x int) int { return x + 1 })
+	}
+}
+
+func BenchmarkMapInto(b *testing.B) {
+	src := make([]int, 1000)
+	buf := make([]int, 0, len(src))
+	b.ReportAllocs()
+	for range b.N {
+		buf = goassist.MapInto(buf[:0], src, func(x int) int { return x + 1 })
+	}
+}
+
+func BenchmarkFilter(b *testing.B) {
+	src := make([]int, 1000)
+	b.ReportAllocs()
+	for range b.N {
+		goassist.Filter(src, func(x int) bool { return x == 0 })
+	}
+}
+
+func BenchmarkFilterInto(b *testing.B) {
+	src := make([]int, 1000)
+	buf := make([]int, 0, len(src))
+	b.ReportAllocs()
+	for range b.N {
+		buf = goassist.FilterInto(buf[:0], src, func(x int) bool { return x == 0 })
+	}
+}
+
+func benchmarkInts() []int {
+	r := seeded()
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = r.IntN(100)
+	}
+	return values
+}
+
+func BenchmarkReduce(b *testing.B) {
+	src := benchmarkInts()
+	for range b.N {
+		goassist.Reduce(src, func(acc, x int) int { return acc + x }, 0)
+	}
+}
+
+func BenchmarkFlatten(b *testing.B) {
+	rows := make([][]int, 100)
+	for i := range rows {
+		rows[i] = benchmarkInts()[:10]
+	}
+	b.ReportAllocs()
+	for range b.N {
+		goassist.Flatten(rows)
+	}
+}
+
+func BenchmarkZip(b *testing.B) {
+	numbers := benchmarkInts()
+	letters := goassist.Map(numbers, func(x int) string { return string(rune('a' + x%26)) })
+	b.ReportAllocs()
+	for range b.N {
+		goassist.Zip(numbers, letters)
+	}
+}
+
+func BenchmarkSorted(b *testing.B) {
+	src := benchmarkInts()
+	b.ReportAllocs()
+	for range b.N {
+		goassist.Sorted(src)
+	}
+}
+
+func BenchmarkCompacted(b *testing.B) {
+	src := goassist.Sorted(benchmarkInts())
+	b.ReportAllocs()
+	for range b.N {
+		goassist.Compacted(src)
+	}
+}
+
+func BenchmarkBinarySearch(b *testing.B) {
+	src := goassist.Sorted(benchmarkInts())
+	for i := range b.N {
+		goassist.BinarySearch(src, i%100)
+	}
+}
+
+func BenchmarkReversed(b *testing.B) {
+	src := benchmarkInts()
+	b.ReportAllocs()
+	for range b.N {
+		goassist.Reversed(src)
+	}
+}
+
+func BenchmarkWithInserted(b *testing.B) {
+	src := benchmarkInts()
+	b.ReportAllocs()
+	for range b.N {
+		goassist.WithInserted(src, len(src)/2, 1, 2, 3)
+	}
+}
@@ -0,0 +1,89 @@
+package goassist_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+type span = goassist.Interval[int]
+
+func TestMergeOverlapping(t *testing.T) {
+	busy := []span{{Start: 13, End: 15}, {Start: 9, End: 10}, {Start: 9, End: 11}, {Start: 11, End: 12}, {Start: 20, End: 20}}
+	merged := goassist.MergeOverlapping(busy)
+	expected := []span{{Start: 9, End: 12}, {Start: 13, End: 15}}
+	if !slices.Equal(merged, expected) {
+		t.Errorf("MergeOverlapping failed: expected %v, got %v", expected, merged)
+	}
+	if busy[0] != (span{Start: 13, End: 15}) {
+		t.Error("MergeOverlapping failed: input should not be modified")
+	}
+}
+
+func TestIntersections(t *testing.T) {
+	alice := []span{{Start: 9, End: 12}, {Start: 14, End: 17}}
+	bob := []span{{Start: 10, End: 15}, {Start: 16, End: 20}}
+	both := goassist.Intersections(alice, bob)
+	expected := []span{{Start: 10, End: 12}, {Start: 14, End: 15}, {Start: 16, End: 17}}
+	if !slices.Equal(both, expected) {
+		t.Errorf("Intersections failed: expected %v, got %v", expected, both)
+	}
+}
+
+func TestGaps(t *testing.T) {
+	busy := []span{{Start: 12, End: 13}, {Start: 9, End: 10}, {Start: 7, End: 8}, {Start: 17, End: 19}}
+	free := goassist.Gaps(busy, span{Start: 8, End: 18})
+	expected := []span{{Start: 8, End: 9}, {Start: 10, End: 12}, {Start: 13, End: 17}}
+	if !slices.Equal(free, expected) {
+		t.Errorf("Gaps failed: expected %v, got %v", expected, free)
+	}
+	if free := goassist.Gaps(nil, span{Start: 1, End: 3}); !slices.Equal(free, []span{{Start: 1, End: 3}}) {
+		t.Errorf("Gaps failed: expected [{1 3}], got %v", free)
+	}
+}
+
+func TestIntervalTree(t *testing.T) {
+	tree := goassist.NewIntervalTree([]span{{Start: 1, End: 5}, {Start: 3, End: 8}, {Start: 10, End: 12}})
+	at4 := tree.Stab(4)
+	goassist.SortFunc(at4, goassist.CompareIntervals[int])
+	if !slices.Equal(at4, []span{{Start: 1, End: 5}, {Start: 3, End: 8}}) {
+		t.Errorf("Stab failed: expected [{1 5} {3 8}], got %v", at4)
+	}
+	during := tree.Overlapping(span{Start: 7, End: 11})
+	goassist.SortFunc(during, goassist.CompareIntervals[int])
+	if !slices.Equal(during, []span{{Start: 3, End: 8}, {Start: 10, End: 12}}) {
+		t.Errorf("Overlapping failed: expected [{3 8} {10 12}], got %v", during)
+	}
+}
+
+func TestIntervalTreeMatchesLinearScan(t *testing.T) {
+	r := rand.New(rand.NewPCG(7, 11))
+	intervals := make([]span, 500)
+	for i := range intervals {
+		start := r.IntN(1000)
+		intervals[i] = span{Start: start, End: start + r.IntN(50)}
+	}
+	tree := goassist.NewIntervalTree(intervals)
+
+	for range 200 {
+		p := r.IntN(1100) - 50
+		expected := goassist.Filter(intervals, func(iv span) bool { return iv.Contains(p) })
+		got := tree.Stab(p)
+		goassist.SortFunc(expected, goassist.CompareIntervals[int])
+		goassist.SortFunc(got, goassist.CompareIntervals[int])
+		if !slices.Equal(got, expected) {
+			t.Fatalf("Stab(%d) failed: expected %v, got %v", p, expected, got)
+		}
+
+		q := span{Start: p, End: p + r.IntN(30)}
+		expected = goassist.Filter(intervals, func(iv span) bool { return iv.Overlaps(q) })
+		got = tree.Overlapping(q)
+		goassist.SortFunc(expected, goassist.CompareIntervals[int])
+		goassist.SortFunc(got, goassist.CompareIntervals[int])
+		if !slices.Equal(got, expected) {
+			t.Fatalf("Overlapping(%v) failed: expected %v, got %v", q, expected, got)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package goassist_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+type order struct {
+	ID     int `json:"id"`
+	Amount int `json:"amount"`
+}
+
+// endlessArray is an infinite JSON array of orders: [{"id":0,...},{"id":1,...},...
+type endlessArray struct {
+	next    int
+	pending string
+}
+
+func (a *endlessArray) Read(p []byte) (int, error) {
+	if a.pending == "" {
+		if a.next == 0 {
+			a.pending = "["
+		} else {
+			a.pending = ","
+		}
+		data, _ := json.Marshal(order{ID: a.next, Amount: a.next * 10})
+		a.pending += string(data)
+		a.next++
+	}
+	n := copy(p, a.pending)
+	a.pending = a.pending[n:]
+	return n, nil
+}
+
+func TestDecodeJSONSeq(t *testing.T) {
+	input := `[{"id":1,"amount":500},{"id":2,"amount":1500}, {"id":3,"amount":2500}]`
+	got := []order{}
+	for o, err := range goassist.DecodeJSONSeq[order](strings.NewReader(input)) {
+		if err != nil {
+			t.Fatalf("DecodeJSONSeq failed: %v", err)
+		}
+		got = append(got, o)
+	}
+	if expected := []order{{1, 500}, {2, 1500}, {3, 2500}}; !slices.Equal(got, expected) {
+		t.Errorf("DecodeJSONSeq failed: expected %v, got %v", expected, got)
+	}
+
+	source := &endlessArray{}
+	count := 0
+	for _, err := range goassist.DecodeJSONSeq[order](source) {
+		if err != nil {
+			t.Fatalf("DecodeJSONSeq failed: %v", err)
+		}
+		if count++; count == 1000 {
+			break
+		}
+	}
+	if source.next > 1100 {
+		t.Errorf("DecodeJSONSeq failed: expected streaming, read %d elements", source.next)
+	}
+}
+
+func TestDecodeJSONSeqErrors(t *testing.T) {
+	cases := []struct {
+		input string
+		valid int
+	}{
+		{`{"id":1}`, 0},
+		{``, 0},
+		{`[{"id":1},{"id":"x"}]`, 1},
+		{`[{"id":1},{"id":2}`, 2},
+	}
+	for _, c := range cases {
+		valid := 0
+		var last error
+		for _, err := range goassist.DecodeJSONSeq[order](strings.NewReader(c.input)) {
+			if err != nil {
+				last = err
+				continue
+			}
+			valid++
+		}
+		if last == nil || valid != c.valid {
+			t.Errorf("DecodeJSONSeq(%q) failed: expected %d elements and an error, got %d, %v", c.input, c.valid, valid, last)
+		}
+	}
+
+	for _, err := range goassist.DecodeJSONSeq[order](strings.NewReader(`"text"`)) {
+		if !errors.Is(err, goassist.ErrNotJSONArray) {
+			t.Errorf("DecodeJSONSeq failed: expected ErrNotJSONArray, got %v", err)
+		}
+	}
+}
+
+func TestEncodeJSONSeq(t *testing.T) {
+	orders := []order{{1, 500}, {2, 1500}}
+	var sb strings.Builder
+	if err := goassist.EncodeJSONSeq(&sb, slices.Values(orders), goassist.JSONArray); err != nil {
+		t.Fatalf("EncodeJSONSeq failed: %v", err)
+	}
+	expected, _ := json.Marshal(orders)
+	if sb.String() != string(expected) {
+		t.Errorf("EncodeJSONSeq failed: expected %s, got %s", expected, sb.String())
+	}
+
+	sb.Reset()
+	goassist.EncodeJSONSeq(&sb, slices.Values([]order{}), goassist.JSONArray)
+	if sb.String() != "[]" {
+		t.Errorf("EncodeJSONSeq failed: expected [], got %s", sb.String())
+	}
+
+	sb.Reset()
+	goassist.EncodeJSONSeq(&sb, slices.Values(orders), goassist.JSONLines)
+	if expected := "{\"id\":1,\"amount\":500}\n{\"id\":2,\"amount\":1500}\n"; sb.String() != expected {
+		t.Errorf("EncodeJSONSeq failed: expected %q, got %q", expected, sb.String())
+	}
+
+	if err := goassist.EncodeJSONSeq(&sb, slices.Values([]any{func() {}}), goassist.JSONLines); err == nil {
+		t.Error("EncodeJSONSeq failed: expected marshal error")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, io.ErrClosedPipe
+}
+
+func TestJSONSeqPipeline(t *testing.T) {
+	var err error
+	orders := goassist.UntilError(goassist.DecodeJSONSeq[order](&endlessArray{}), &err)
+	large := goassist.FilterSeq(orders, func(o order) bool { return o.Amount >= 100 })
+	ids := goassist.MapSeq(large, func(o order) int { return o.ID })
+
+	var sb strings.Builder
+	limited := func(yield func(int) bool) {
+		n := 0
+		for id := range ids {
+			if n++; n > 3 || !yield(id) {
+				return
+			}
+		}
+	}
+	if werr := goassist.EncodeJSONSeq(&sb, limited, goassist.JSONArray); werr != nil || err != nil {
+		t.Fatalf("pipeline failed: %v, %v", werr, err)
+	}
+	if sb.String() != "[10,11,12]" {
+		t.Errorf("pipeline failed: expected [10,11,12], got %s", sb.String())
+	}
+
+	bad := goassist.UntilError(goassist.DecodeJSONSeq[order](strings.NewReader(`[{"id":1},oops]`)), &err)
+	if n := len(slices.Collect(bad)); n != 1 || err == nil {
+		t.Errorf("UntilError failed: expected 1 element and an error, got %d, %v", n, err)
+	}
+
+	if werr := goassist.EncodeJSONSeq(failingWriter{}, slices.Values(make([]int, 10000)), goassist.JSONLines); !errors.Is(werr, io.ErrClosedPipe) {
+		t.Errorf("EncodeJSONSeq failed: expected write error, got %v", werr)
+	}
+}
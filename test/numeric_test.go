@@ -0,0 +1,184 @@
+package goassist_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+// Named element types have no specialized kernel, so they exercise the generic path.
+type (
+	genericFloat float64
+	genericInt   int32
+)
+
+func TestSum(t *testing.T) {
+	if sum := goassist.Sum([]float64{1.5, 2.5, 4}); sum != 8 {
+		t.Errorf("Sum failed: expected 8, got %v", sum)
+	}
+	if sum := goassist.Sum([]int{}); sum != 0 {
+		t.Errorf("Sum failed: expected 0 for empty slice, got %d", sum)
+	}
+	if sum := goassist.Sum([]uint8{200, 100}); sum != 44 {
+		t.Errorf("Sum failed: expected wrap-around to 44, got %d", sum)
+	}
+}
+
+func TestDot(t *testing.T) {
+	if dot := goassist.Dot([]float64{0.5, 0.25, 0.25}, []float64{80, 60, 100}); dot != 80 {
+		t.Errorf("Dot failed: expected 80, got %v", dot)
+	}
+	if dot := goassist.Dot([]int64{1, 2, 3, 4, 5}, []int64{1, 1}); dot != 3 {
+		t.Errorf("Dot failed: expected 3 for unequal lengths, got %d", dot)
+	}
+}
+
+func TestScale(t *testing.T) {
+	numbers := []int64{1, 25, 100}
+	cents := goassist.Scale(numbers, 100)
+	if !slices.Equal(cents, []int64{100, 2500, 10000}) {
+		t.Errorf("Scale failed: got %v", cents)
+	}
+	if numbers[0] != 1 {
+		t.Error("Scale failed: modified the input slice")
+	}
+}
+
+func TestAdd(t *testing.T) {
+	totals := goassist.Add([]int32{1, 2, 3}, []int32{10, 20, 30})
+	if !slices.Equal(totals, []int32{11, 22, 33}) {
+		t.Errorf("Add failed: got %v", totals)
+	}
+	if short := goassist.Add([]float32{1, 2, 3}, []float32{1}); !slices.Equal(short, []float32{2}) {
+		t.Errorf("Add failed: expected length of the shorter slice, got %v", short)
+	}
+}
+
+// TestNumericKernels checks that the specialized kernels agree with the generic path for every
+// remainder length. The floats are small integers, so that their sums are exact in any order.
+func TestNumericKernels(t *testing.T) {
+	r := seeded()
+	for n := range 40 {
+		a, b := make([]float64, n), make([]float64, n)
+		for i := range n {
+			a[i], b[i] = float64(r.IntN(201)-100), float64(r.IntN(201)-100)
+		}
+		ga, gb := toGeneric[genericFloat](a), toGeneric[genericFloat](b)
+		ia, ib := toGeneric[int32](a), toGeneric[int32](b)
+		na, nb := toGeneric[genericInt](a), toGeneric[genericInt](b)
+
+		if got, want := goassist.Sum(a), float64(goassist.Sum(ga)); got != want {
+			t.Errorf("Sum failed for n=%d: expected %v, got %v", n, want, got)
+		}
+		if got, want := goassist.Dot(a, b), float64(goassist.Dot(ga, gb)); got != want {
+			t.Errorf("Dot failed for n=%d: expected %v, got %v", n, want, got)
+		}
+		if got, want := goassist.Dot(ia, ib), int32(goassist.Dot(na, nb)); got != want {
+			t.Errorf("Dot failed for n=%d: expected %v, got %v", n, want, got)
+		}
+		if got, want := goassist.Scale(ia, 3), goassist.Scale(na, 3); !slices.Equal(toGeneric[genericInt](got), want) {
+			t.Errorf("Scale failed for n=%d: expected %v, got %v", n, want, got)
+		}
+		if got, want := goassist.Add(toGeneric[float32](a), toGeneric[float32](b)), goassist.Add(ga, gb); !slices.Equal(toGeneric[genericFloat](got), want) {
+			t.Errorf("Add failed for n=%d: expected %v, got %v", n, want, got)
+		}
+		if n > 0 {
+			if got, want := goassist.Min(a), slices.Min(a); got != want {
+				t.Errorf("Min failed for n=%d: expected %v, got %v", n, want, got)
+			}
+			if got, want := goassist.Max(toGeneric[int64](a)), slices.Max(toGeneric[int64](a)); got != want {
+				t.Errorf("Max failed for n=%d: expected %v, got %v", n, want, got)
+			}
+		}
+	}
+}
+
+func TestMinMaxNaN(t *testing.T) {
+	for pos := range 6 {
+		values := []float64{3, 1, 4, 1, 5, 9}
+		values[pos] = math.NaN()
+		if got := goassist.Min(values); !math.IsNaN(got) {
+			t.Errorf("Min failed: expected NaN at position %d to propagate, got %v", pos, got)
+		}
+		if got := goassist.Max(toGeneric[float32](values)); !math.IsNaN(float64(got)) {
+			t.Errorf("Max failed: expected NaN at position %d to propagate, got %v", pos, got)
+		}
+	}
+}
+
+func TestNumericKernelsNoAllocs(t *testing.T) {
+	values := make([]float64, 100)
+	allocs := testing.AllocsPerRun(100, func() {
+		goassist.Sum(values)
+		goassist.Dot(values, values)
+		goassist.Min(values)
+		goassist.Max(values)
+	})
+	if allocs != 0 {
+		t.Errorf("Sum, Dot, Min and Max failed: expected no allocations, got %v", allocs)
+	}
+}
+
+func toGeneric[E, F goassist.Number](s []F) []E {
+	result := make([]E, len(s))
+	for i, v := range s {
+		result[i] = E(v)
+	}
+	return result
+}
+
+func benchmarkFloats() []float64 {
+	r := seeded()
+	values := make([]float64, 4096)
+	for i := range values {
+		values[i] = r.Float64()
+	}
+	return values
+}
+
+func BenchmarkSum(b *testing.B) {
+	values := benchmarkFloats()
+	b.Run("float64", func(b *testing.B) {
+		for range b.N {
+			goassist.Sum(values)
+		}
+	})
+	b.Run("generic", func(b *testing.B) {
+		values := toGeneric[genericFloat](values)
+		for range b.N {
+			goassist.Sum(values)
+		}
+	})
+}
+
+func BenchmarkDot(b *testing.B) {
+	values := benchmarkFloats()
+	b.Run("float64", func(b *testing.B) {
+		for range b.N {
+			goassist.Dot(values, values)
+		}
+	})
+	b.Run("generic", func(b *testing.B) {
+		values := toGeneric[genericFloat](values)
+		for range b.N {
+			goassist.Dot(values, values)
+		}
+	})
+}
+
+func BenchmarkMin(b *testing.B) {
+	values := benchmarkFloats()
+	b.Run("float64", func(b *testing.B) {
+		for range b.N {
+			goassist.Min(values)
+		}
+	})
+	b.Run("generic", func(b *testing.B) {
+		values := toGeneric[genericFloat](values)
+		for range b.N {
+			goassist.Min(values)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package goassist_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+func TestOption(t *testing.T) {
+	some := goassist.OptionOf(3)
+	none := goassist.None[int]()
+	var zero goassist.Option[int]
+
+	if !some.IsSome() || some.IsNone() || !none.IsNone() || zero.IsSome() {
+		t.Error("Option failed: unexpected IsSome/IsNone")
+	}
+	if v, ok := some.Get(); v != 3 || !ok {
+		t.Errorf("Option.Get failed: got %v, %v", v, ok)
+	}
+	if some.Unwrap() != 3 || none.UnwrapOr(7) != 7 || none.UnwrapOrElse(func() int { return 8 }) != 8 {
+		t.Error("Option failed: unexpected unwrapped value")
+	}
+	if got := none.OrElse(func() goassist.Option[int] { return goassist.OptionOf(9) }); got.Unwrap() != 9 {
+		t.Errorf("Option.OrElse failed: got %v", got)
+	}
+	if got := some.OrElse(func() goassist.Option[int] { return goassist.OptionOf(9) }); got.Unwrap() != 3 {
+		t.Errorf("Option.OrElse failed: got %v", got)
+	}
+	if some.String() != "Some(3)" || none.String() != "None" {
+		t.Errorf("Option.String failed: got %s and %s", some, none)
+	}
+
+	length := goassist.MapOption(goassist.OptionOf("hello"), func(s string) int { return len(s) })
+	if length.Unwrap() != 5 || goassist.MapOption(none, strconv.Itoa).IsSome() {
+		t.Errorf("MapOption failed: got %v", length)
+	}
+	half := func(n int) goassist.Option[int] {
+		return goassist.OptionFrom(n/2, n%2 == 0)
+	}
+	if goassist.AndThenOption(goassist.OptionOf(4), half).Unwrap() != 2 || goassist.AndThenOption(some, half).IsSome() {
+		t.Error("AndThenOption failed")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Option.Unwrap failed: expected panic on None")
+		}
+	}()
+	none.Unwrap()
+}
+
+func TestResult(t *testing.T) {
+	ok := goassist.ResultOf(strconv.Atoi("42"))
+	bad := goassist.ResultOf(strconv.Atoi("x"))
+
+	if !ok.IsOk() || ok.IsErr() || !bad.IsErr() || bad.Err() == nil || ok.Err() != nil {
+		t.Error("Result failed: unexpected IsOk/IsErr")
+	}
+	if v, err := ok.Get(); v != 42 || err != nil {
+		t.Errorf("Result.Get failed: got %v, %v", v, err)
+	}
+	if ok.Unwrap() != 42 || bad.UnwrapOr(-1) != -1 || bad.UnwrapOrElse(func(error) int { return -2 }) != -2 {
+		t.Error("Result failed: unexpected unwrapped value")
+	}
+	if got := bad.OrElse(func(error) goassist.Result[int] { return goassist.Ok(0) }); got.Unwrap() != 0 {
+		t.Errorf("Result.OrElse failed: got %v", got)
+	}
+	if ok.String() != "Ok(42)" || bad.Option().IsSome() || ok.Option().Unwrap() != 42 {
+		t.Errorf("Result failed: got %s", ok)
+	}
+
+	doubled := goassist.MapResult(ok, func(n int) int { return n * 2 })
+	if doubled.Unwrap() != 84 || !errors.Is(goassist.MapResult(bad, strconv.Itoa).Err(), strconv.ErrSyntax) {
+		t.Errorf("MapResult failed: got %v", doubled)
+	}
+	errTooBig := errors.New("too big")
+	check := func(n int) goassist.Result[int] {
+		if n > 10 {
+			return goassist.Err[int](errTooBig)
+		}
+		return goassist.Ok(n)
+	}
+	if !errors.Is(goassist.AndThenResult(ok, check).Err(), errTooBig) || goassist.AndThenResult(goassist.Ok(3), check).Unwrap() != 3 {
+		t.Error("AndThenResult failed")
+	}
+
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, strconv.ErrSyntax) {
+			t.Errorf("Result.Unwrap failed: expected panic with the error, got %v", err)
+		}
+	}()
+	bad.Unwrap()
+}
+
+func TestOptHelpers(t *testing.T) {
+	numbers := []int{4, 2, 5, 1, 3}
+	empty := []int{}
+
+	if got := goassist.FindOpt(numbers, func(x int) bool { return x > 4 }); got.Unwrap() != 5 {
+		t.Errorf("FindOpt failed: got %v", got)
+	}
+	if got := goassist.FindOpt(numbers, func(x int) bool { return x > 10 }); got.IsSome() {
+		t.Errorf("FindOpt failed: got %v", got)
+	}
+	if goassist.MinOpt(numbers).Unwrap() != 1 || goassist.MaxOpt(numbers).Unwrap() != 5 {
+		t.Error("MinOpt/MaxOpt failed")
+	}
+	if goassist.MinOpt(empty).IsSome() || goassist.MaxOpt(empty).IsSome() {
+		t.Error("MinOpt/MaxOpt failed: expected None for empty slice")
+	}
+	if goassist.FirstOpt(numbers).Unwrap() != 4 || goassist.LastOpt(numbers).Unwrap() != 3 {
+		t.Error("FirstOpt/LastOpt failed")
+	}
+	if goassist.FirstOpt(empty).IsSome() || goassist.LastOpt(empty).IsSome() {
+		t.Error("FirstOpt/LastOpt failed: expected None for empty slice")
+	}
+	if goassist.At(numbers, 2).Unwrap() != 5 || goassist.At(numbers, 5).IsSome() || goassist.At(numbers, -6).IsSome() {
+		t.Error("At failed")
+	}
+}
@@ -0,0 +1,321 @@
+package goassist_test
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sync"
+	"testing"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+func TestPersistentVector(t *testing.T) {
+	v1 := goassist.PersistentVectorOf([]string{"a", "b"})
+	v2 := v1.Append("c")
+	v3 := v2.Set(0, "z")
+	if v1.String() != "[a b]" || v2.String() != "[a b c]" || v3.String() != "[z b c]" {
+		t.Errorf("PersistentVector failed: got %v, %v, %v", v1, v2, v3)
+	}
+
+	var empty goassist.PersistentVector[int]
+	if empty.Len() != 0 || len(empty.ToSlice()) != 0 {
+		t.Errorf("PersistentVector failed: expected empty zero value, got %v", empty)
+	}
+	if got := empty.Append(1, 2, 3); !slices.Equal(got.ToSlice(), []int{1, 2, 3}) || empty.Len() != 0 {
+		t.Errorf("PersistentVector.Append failed: got %v", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("PersistentVector.Get failed: expected panic for index out of range")
+		}
+	}()
+	v1.Get(2)
+}
+
+func TestPersistentVectorSharing(t *testing.T) {
+	// Sizes around the tail, the first root split and the second level of the trie.
+	for _, n := range []int{1, 31, 32, 33, 64, 1024, 1056, 1057, 33824} {
+		model := make([]int, 0, n)
+		var v goassist.PersistentVector[int]
+		versions := []goassist.PersistentVector[int]{}
+		for i := range n {
+			v = v.Append(i)
+			model = append(model, i)
+			if i%97 == 0 {
+				versions = append(versions, v)
+			}
+		}
+		if !slices.Equal(v.ToSlice(), model) {
+			t.Fatalf("PersistentVector(%d) failed: Append gave a different sequence", n)
+		}
+
+		changed := v
+		for i := 0; i < n; i += 7 {
+			changed = changed.Set(i, -i)
+		}
+		for i := range n {
+			if v.Get(i) != i {
+				t.Fatalf("PersistentVector(%d) failed: Set changed the original at %d", n, i)
+			}
+			if expected := i; i%7 == 0 && changed.Get(i) != -expected {
+				t.Fatalf("PersistentVector(%d) failed: Set lost the value at %d", n, i)
+			}
+		}
+		for j, old := range versions {
+			if old.Len() != j*97+1 || old.Get(old.Len()-1) != old.Len()-1 {
+				t.Fatalf("PersistentVector(%d) failed: old version %d was modified", n, j)
+			}
+		}
+	}
+}
+
+func TestPersistentVectorBuilder(t *testing.T) {
+	var b goassist.PersistentVectorBuilder[int]
+	for i := range 5000 {
+		b.Append(i)
+	}
+	v1 := b.Persistent()
+
+	b.Set(0, -1)
+	b.Set(4999, -1)
+	b.Append(5000)
+	v2 := b.Persistent()
+	if v1.Get(0) != 0 || v1.Get(4999) != 4999 || v1.Len() != 5000 {
+		t.Error("PersistentVectorBuilder failed: changes after Persistent leaked into the vector")
+	}
+	if v2.Get(0) != -1 || v2.Get(4999) != -1 || v2.Get(5000) != 5000 || b.Len() != 5001 || b.Get(5000) != 5000 {
+		t.Error("PersistentVectorBuilder failed: changes were lost")
+	}
+
+	nb := v1.Builder()
+	nb.Set(100, -100)
+	if v1.Get(100) != 100 || nb.Persistent().Get(100) != -100 {
+		t.Error("PersistentVector.Builder failed: builder changed the vector")
+	}
+
+	all := []int{}
+	for i, x := range v2.All() {
+		if i != len(all) {
+			t.Fatalf("PersistentVector.All failed: unexpected index %d", i)
+		}
+		all = append(all, x)
+		if i == 9 {
+			break
+		}
+	}
+	if !slices.Equal(all, []int{-1, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("PersistentVector.All failed: got %v", all)
+	}
+}
+
+func TestPersistentVectorConcurrentReads(t *testing.T) {
+	v := goassist.PersistentVectorOf(goassist.Map(make([]int, 10000), func(int) int { return 1 }))
+	var wg sync.WaitGroup
+	for w := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			local := v
+			for i := w; i < local.Len(); i += 8 {
+				local = local.Set(i, 2)
+			}
+		}()
+	}
+	wg.Wait()
+	if sum := goassist.Reduce(v.ToSlice(), func(acc, x int) int { return acc + x }, 0); sum != 10000 {
+		t.Errorf("PersistentVector failed: expected the shared vector to be unchanged, got sum %d", sum)
+	}
+}
+
+func TestPersistentMap(t *testing.T) {
+	m1 := goassist.PersistentMap[string, int]{}.Set("a", 1)
+	m2 := m1.Set("b", 2)
+	m3 := m2.Delete("a")
+	if m1.String() != "map[a:1]" || m2.String() != "map[a:1 b:2]" || m3.String() != "map[b:2]" {
+		t.Errorf("PersistentMap failed: got %v, %v, %v", m1, m2, m3)
+	}
+	if v, ok := m2.Get("b"); !ok || v != 2 {
+		t.Errorf("PersistentMap.Get failed: got %v, %v", v, ok)
+	}
+	if _, ok := m3.Get("a"); ok {
+		t.Error("PersistentMap.Get failed: expected deleted key to be missing")
+	}
+	if same := m3.Delete("missing"); same.Len() != 1 {
+		t.Errorf("PersistentMap.Delete failed: got %v", same)
+	}
+	if m := m2.Set("a", 10); m.Len() != 2 || m1.String() != "map[a:1]" {
+		t.Errorf("PersistentMap.Set failed: got %v", m)
+	}
+}
+
+func TestPersistentMapModel(t *testing.T) {
+	r := seeded()
+	model := map[int]int{}
+	var m goassist.PersistentMap[int, int]
+	var snapshot goassist.PersistentMap[int, int]
+	var snapshotModel map[int]int
+	for step := range 50000 {
+		k := r.IntN(5000)
+		if r.IntN(3) == 0 {
+			delete(model, k)
+			m = m.Delete(k)
+		} else {
+			model[k] = step
+			m = m.Set(k, step)
+		}
+		if step == 25000 {
+			snapshot, snapshotModel = m, maps.Clone(model)
+		}
+	}
+	if m.Len() != len(model) || !maps.Equal(m.ToMap(), model) {
+		t.Fatalf("PersistentMap failed: expected %d entries matching the model, got %d", len(model), m.Len())
+	}
+	if !maps.Equal(snapshot.ToMap(), snapshotModel) {
+		t.Error("PersistentMap failed: an older version was modified")
+	}
+	for k := range 5000 {
+		v, ok := m.Get(k)
+		if expected, found := model[k]; ok != found || v != expected {
+			t.Fatalf("PersistentMap.Get(%d) failed: expected %v, %v, got %v, %v", k, expected, found, v, ok)
+		}
+	}
+}
+
+// loudKey has a GoString method that returns something different on every call.
+type loudKey struct {
+	ID int
+}
+
+var loudCalls int
+
+func (k loudKey) GoString() string {
+	loudCalls++
+	return fmt.Sprintf("loudKey#%d", loudCalls)
+}
+
+func TestPersistentMapCompositeKeys(t *testing.T) {
+	type point struct {
+		X, Y float64
+	}
+	negZero := math.Copysign(0, -1)
+	m := goassist.PersistentMap[point, string]{}.Set(point{0, 1}, "positive").Set(point{negZero, 1}, "negative")
+	if m.Len() != 1 || len(m.ToMap()) != 1 {
+		t.Errorf("PersistentMap failed: expected -0 and +0 to be the same key, got %v", m)
+	}
+	if v, ok := m.Get(point{negZero, 1}); !ok || v != "negative" {
+		t.Errorf("PersistentMap.Get failed: expected negative, got %q, %v", v, ok)
+	}
+
+	loud := goassist.PersistentMap[loudKey, int]{}.Set(loudKey{1}, 1).Set(loudKey{1}, 2)
+	if v, ok := loud.Get(loudKey{1}); loud.Len() != 1 || !ok || v != 2 {
+		t.Errorf("PersistentMap failed: expected GoString to be ignored, got %v, %v, len %d", v, ok, loud.Len())
+	}
+
+	var mixed goassist.PersistentMap[any, int]
+	mixed = mixed.Set([2]float64{negZero, 1}, 1).Set(point{negZero, 0}, 2).Set(any(nil), 3)
+	for key, want := range map[any]int{[2]float64{0, 1}: 1, point{0, negZero}: 2, nil: 3} {
+		if v, ok := mixed.Get(key); !ok || v != want {
+			t.Errorf("PersistentMap.Get(%v) failed: expected %d, got %d, %v", key, want, v, ok)
+		}
+	}
+
+	// Compare with a built-in map on struct keys with unexported and interface fields.
+	type record struct {
+		name  string
+		score float32
+		tag   any
+	}
+	r := seeded()
+	model := map[record]int{}
+	var pm goassist.PersistentMap[record, int]
+	for step := range 5000 {
+		k := record{name: fmt.Sprint(r.IntN(20)), score: float32(r.IntN(5)) - 2, tag: r.IntN(3)}
+		if k.score == 0 && r.IntN(2) == 0 {
+			k.score = float32(negZero)
+		}
+		if r.IntN(4) == 0 {
+			delete(model, k)
+			pm = pm.Delete(k)
+		} else {
+			model[k] = step
+			pm = pm.Set(k, step)
+		}
+	}
+	if pm.Len() != len(model) || !maps.Equal(pm.ToMap(), model) {
+		t.Errorf("PersistentMap failed: expected %d entries matching the model, got %d", len(model), pm.Len())
+	}
+}
+
+func TestPersistentMapBuilder(t *testing.T) {
+	type key struct {
+		Region string
+		ID     int
+	}
+	var b goassist.PersistentMapBuilder[key, string]
+	for i := range 3000 {
+		b.Set(key{"eu", i}, fmt.Sprint(i))
+	}
+	m1 := b.Persistent()
+	for i := range 1000 {
+		b.Delete(key{"eu", i})
+	}
+	b.Set(key{"us", 1}, "one")
+	m2 := b.Persistent()
+
+	if m1.Len() != 3000 || m2.Len() != 2001 || b.Len() != 2001 {
+		t.Errorf("PersistentMapBuilder failed: got lengths %d, %d, %d", m1.Len(), m2.Len(), b.Len())
+	}
+	if v, ok := m1.Get(key{"eu", 5}); !ok || v != "5" {
+		t.Error("PersistentMapBuilder failed: changes after Persistent leaked into the map")
+	}
+	if _, ok := m2.Get(key{"eu", 5}); ok {
+		t.Error("PersistentMapBuilder failed: expected deleted key to be missing")
+	}
+	if b.Delete(key{"eu", 5}) {
+		t.Error("PersistentMapBuilder.Delete failed: expected false for a missing key")
+	}
+
+	converted := goassist.PersistentMapOf(map[float64]bool{0: true, 1.5: false})
+	if v, ok := converted.Get(-0.0); !ok || !v {
+		t.Error("PersistentMap failed: expected -0 and +0 to be the same key")
+	}
+	var anyKeys goassist.PersistentMap[any, int]
+	anyKeys = anyKeys.Set(1, 1).Set("1", 2).Set(nil, 3)
+	if v, _ := anyKeys.Get("1"); v != 2 || anyKeys.Len() != 3 {
+		t.Errorf("PersistentMap failed: got %v", anyKeys)
+	}
+	if v, ok := anyKeys.Get(nil); !ok || v != 3 {
+		t.Error("PersistentMap failed: expected nil key to be found")
+	}
+}
+
+func BenchmarkPersistentVectorAppend(b *testing.B) {
+	for range b.N {
+		var v goassist.PersistentVector[int]
+		for i := range 10000 {
+			v = v.Append(i)
+		}
+	}
+}
+
+func BenchmarkPersistentVectorBuilder(b *testing.B) {
+	for range b.N {
+		var vb goassist.PersistentVectorBuilder[int]
+		for i := range 10000 {
+			vb.Append(i)
+		}
+		vb.Persistent()
+	}
+}
+
+func BenchmarkPersistentMapSet(b *testing.B) {
+	for range b.N {
+		var m goassist.PersistentMap[int, int]
+		for i := range 10000 {
+			m = m.Set(i, i)
+		}
+	}
+}
@@ -0,0 +1,357 @@
+package goassist_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+// instantClock never blocks: every wait advances the clock immediately and is recorded.
+type instantClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *instantClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *instantClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.sleeps = append(c.sleeps, d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func (c *instantClock) total() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var total time.Duration
+	for _, d := range c.sleeps {
+		total += d
+	}
+	return total
+}
+
+// manualClock reports every After call on calls; the timer fires only when the test sends on it.
+// Now never advances, so token buckets are not refilled.
+type manualClock struct {
+	calls chan manualTimer
+}
+
+type manualTimer struct {
+	d  time.Duration
+	ch chan time.Time
+}
+
+func newManualClock() *manualClock {
+	return &manualClock{calls: make(chan manualTimer, 16)}
+}
+
+func (c *manualClock) Now() time.Time { return time.Time{} }
+
+func (c *manualClock) After(d time.Duration) <-chan time.Time {
+	timer := manualTimer{d: d, ch: make(chan time.Time, 1)}
+	c.calls <- timer
+	return timer.ch
+}
+
+// next returns the next timer and checks its duration.
+func (c *manualClock) next(t *testing.T, want time.Duration) manualTimer {
+	t.Helper()
+	select {
+	case timer := <-c.calls:
+		if timer.d != want {
+			t.Fatalf("expected a %v timer, got %v", want, timer.d)
+		}
+		return timer
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected a %v timer, got none", want)
+		return manualTimer{}
+	}
+}
+
+func (m manualTimer) fire() { m.ch <- time.Time{} }
+
+func fetch(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return string(body), nil
+}
+
+func TestMapWithPolicy(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		id, _ := strconv.Atoi(r.URL.Query().Get("id"))
+		fmt.Fprint(w, id*id)
+	}))
+	defer server.Close()
+
+	ids := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	squares, err := goassist.MapWithPolicy(context.Background(), ids, goassist.Policy{MaxConcurrency: 3},
+		func(ctx context.Context, id int) (string, error) {
+			return fetch(ctx, server.URL+"?id="+strconv.Itoa(id))
+		})
+	if err != nil {
+		t.Fatalf("MapWithPolicy failed: unexpected error %v", err)
+	}
+	for i, id := range ids {
+		if squares[i] != strconv.Itoa(id*id) {
+			t.Errorf("MapWithPolicy failed: expected %d, got %s", id*id, squares[i])
+		}
+	}
+	if peak.Load() > 3 {
+		t.Errorf("MapWithPolicy failed: expected at most 3 concurrent requests, got %d", peak.Load())
+	}
+}
+
+func TestMapWithPolicyRetry(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Query().Get("id")
+		mu.Lock()
+		calls[id]++
+		n := calls[id]
+		mu.Unlock()
+		if n < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, "ok-"+id)
+	}))
+	defer server.Close()
+
+	clock := &instantClock{}
+	policy := goassist.Policy{
+		MaxRetries: 2,
+		BaseDelay:  10 * time.Millisecond,
+		Clock:      clock,
+	}
+	result, err := goassist.MapWithPolicy(context.Background(), []string{"a"}, policy,
+		func(ctx context.Context, id string) (string, error) {
+			return fetch(ctx, server.URL+"?id="+id)
+		})
+	if err != nil || result[0] != "ok-a" {
+		t.Fatalf("MapWithPolicy failed: expected ok-a, got %q, %v", result[0], err)
+	}
+	expected := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond}
+	if len(clock.sleeps) != len(expected) {
+		t.Fatalf("MapWithPolicy failed: expected backoffs %v, got %v", expected, clock.sleeps)
+	}
+	for i, d := range clock.sleeps {
+		if d != expected[i] {
+			t.Errorf("MapWithPolicy failed: expected backoff %v, got %v", expected[i], d)
+		}
+	}
+
+	policy.MaxRetries = 1
+	_, err = goassist.MapWithPolicy(context.Background(), []string{"b"}, policy,
+		func(ctx context.Context, id string) (string, error) {
+			return fetch(ctx, server.URL+"?id="+id)
+		})
+	var itemErr *goassist.ItemError
+	if !errors.As(err, &itemErr) || itemErr.Index != 0 {
+		t.Errorf("MapWithPolicy failed: expected ItemError for index 0, got %v", err)
+	}
+}
+
+func TestMapWithPolicyBackoffWithoutMaxDelay(t *testing.T) {
+	clock := &instantClock{}
+	policy := goassist.Policy{MaxRetries: 70, BaseDelay: 100 * time.Millisecond, Clock: clock}
+	_, err := goassist.MapWithPolicy(context.Background(), []int{1}, policy, func(context.Context, int) (int, error) {
+		return 0, errors.New("unavailable")
+	})
+	if err == nil {
+		t.Fatal("MapWithPolicy failed: expected an error")
+	}
+	if len(clock.sleeps) != 70 {
+		t.Fatalf("MapWithPolicy failed: expected 70 backoffs, got %d", len(clock.sleeps))
+	}
+	for i, d := range clock.sleeps {
+		if i > 0 && d < clock.sleeps[i-1] {
+			t.Fatalf("MapWithPolicy failed: backoff %d is %v, shorter than the previous %v", i, d, clock.sleeps[i-1])
+		}
+	}
+	if last := clock.sleeps[len(clock.sleeps)-1]; last != time.Duration(math.MaxInt64) {
+		t.Errorf("MapWithPolicy failed: expected the backoff to saturate, got %v", last)
+	}
+}
+
+func TestPolicyJitter(t *testing.T) {
+	clock := &instantClock{}
+	policy := goassist.Policy{
+		MaxRetries: 5,
+		BaseDelay:  100 * time.Millisecond,
+		MaxDelay:   300 * time.Millisecond,
+		Jitter:     0.5,
+		Clock:      clock,
+		Rand:       rand.New(rand.NewPCG(1, 2)),
+	}
+	err := goassist.ForEachWithPolicy(context.Background(), []int{1}, policy, func(context.Context, int) error {
+		return errors.New("boom")
+	})
+	if err == nil {
+		t.Fatal("ForEachWithPolicy failed: expected error, got nil")
+	}
+	ceilings := []time.Duration{100, 200, 300, 300, 300}
+	for i, d := range clock.sleeps {
+		ceiling := ceilings[i] * time.Millisecond
+		if d > ceiling || d < ceiling/2 {
+			t.Errorf("ForEachWithPolicy failed: expected backoff in [%v, %v], got %v", ceiling/2, ceiling, d)
+		}
+	}
+}
+
+func TestForEachWithPolicyRateLimit(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+	}))
+	defer server.Close()
+
+	clock := &instantClock{}
+	policy := goassist.Policy{Rate: 10, Burst: 1, Clock: clock}
+	err := goassist.ForEachWithPolicy(context.Background(), []int{1, 2, 3, 4, 5}, policy,
+		func(ctx context.Context, id int) error {
+			_, err := fetch(ctx, server.URL)
+			return err
+		})
+	if err != nil {
+		t.Fatalf("ForEachWithPolicy failed: unexpected error %v", err)
+	}
+	if hits.Load() != 5 {
+		t.Errorf("ForEachWithPolicy failed: expected 5 requests, got %d", hits.Load())
+	}
+	if total := clock.total(); total != 400*time.Millisecond {
+		t.Errorf("ForEachWithPolicy failed: expected 400ms of throttling, got %v", total)
+	}
+}
+
+func TestForEachWithPolicyTimeout(t *testing.T) {
+	policy := goassist.Policy{Timeout: time.Second, Clock: &instantClock{}}
+	err := goassist.ForEachWithPolicy(context.Background(), []int{1}, policy, func(ctx context.Context, _ int) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, goassist.ErrItemTimeout) {
+		t.Errorf("ForEachWithPolicy failed: expected ErrItemTimeout, got %v", err)
+	}
+}
+
+func TestForEachWithPolicyItemTimeout(t *testing.T) {
+	clock := newManualClock()
+	policy := goassist.Policy{ItemTimeout: 10 * time.Second, MaxRetries: 100, BaseDelay: time.Second, Clock: clock}
+	var attempts atomic.Int32
+	done := make(chan error)
+	go func() {
+		done <- goassist.ForEachWithPolicy(context.Background(), []int{1}, policy, func(context.Context, int) error {
+			attempts.Add(1)
+			return errors.New("unavailable")
+		})
+	}()
+
+	itemTimer := clock.next(t, 10*time.Second)
+	clock.next(t, time.Second).fire()
+	clock.next(t, 2*time.Second)
+	itemTimer.fire()
+
+	err := <-done
+	if !errors.Is(err, goassist.ErrItemTimeout) {
+		t.Errorf("ForEachWithPolicy failed: expected ErrItemTimeout, got %v", err)
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("ForEachWithPolicy failed: expected the item timeout to stop retries after 2 attempts, got %d", attempts.Load())
+	}
+}
+
+func TestForEachWithPolicyReturnsCanceledToken(t *testing.T) {
+	clock := newManualClock()
+	policy := goassist.Policy{Rate: 1, Burst: 1, ItemTimeout: 10 * time.Second, Clock: clock}
+	done := make(chan error)
+	go func() {
+		done <- goassist.ForEachWithPolicy(context.Background(), []int{1, 2, 3}, policy, func(context.Context, int) error {
+			return nil
+		})
+	}()
+
+	// Item 1 takes the only token. Item 2 waits a second for the next one, but times out first.
+	clock.next(t, 10*time.Second)
+	item2 := clock.next(t, 10*time.Second)
+	clock.next(t, time.Second)
+	item2.fire()
+
+	// Item 3 must wait for one token, not for the one item 2 reserved and never used.
+	clock.next(t, 10*time.Second)
+	clock.next(t, time.Second).fire()
+
+	err := <-done
+	if !errors.Is(err, goassist.ErrItemTimeout) {
+		t.Errorf("ForEachWithPolicy failed: expected ErrItemTimeout for item 2, got %v", err)
+	}
+	var itemErr *goassist.ItemError
+	if !errors.As(err, &itemErr) || itemErr.Index != 1 {
+		t.Errorf("ForEachWithPolicy failed: expected only item 1 to fail, got %v", err)
+	}
+}
+
+func TestForEachWithPolicyCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	err := goassist.ForEachWithPolicy(ctx, []int{1, 2, 3, 4}, goassist.Policy{}, func(context.Context, int) error {
+		calls.Add(1)
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ForEachWithPolicy failed: expected context.Canceled, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("ForEachWithPolicy failed: expected 1 call, got %d", calls.Load())
+	}
+	var itemErr *goassist.ItemError
+	if errors.As(err, &itemErr) {
+		t.Errorf("ForEachWithPolicy failed: expected no item errors after cancellation, got %v", err)
+	}
+}
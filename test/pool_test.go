@@ -0,0 +1,77 @@
+package goassist_test
+
+import (
+	"sync"
+	"testing"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+func TestSlicePool(t *testing.T) {
+	pool := goassist.NewSlicePool[*int](16)
+	buf := pool.Get()
+	if len(buf) != 0 || cap(buf) < 16 {
+		t.Fatalf("SlicePool.Get failed: expected empty slice with capacity 16, got len %d cap %d", len(buf), cap(buf))
+	}
+
+	n := 7
+	buf = append(buf, &n, &n)
+	pool.Put(buf)
+	if buf[:2][0] != nil || buf[:2][1] != nil {
+		t.Error("SlicePool.Put failed: expected elements to be cleared")
+	}
+
+	reused := pool.Get()
+	if len(reused) != 0 || cap(reused) < 16 {
+		t.Errorf("SlicePool.Get failed: got len %d cap %d", len(reused), cap(reused))
+	}
+	pool.Put(nil)
+}
+
+func TestSlicePoolAllocations(t *testing.T) {
+	pool := goassist.NewSlicePool[int](64)
+	src := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	pool.Put(pool.Get())
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		buf := pool.Get()
+		buf = goassist.MapInto(buf, src, func(x int) int { return x * x })
+		pool.Put(buf)
+	})
+	if allocs != 0 {
+		t.Errorf("SlicePool failed: expected no allocations, got %v", allocs)
+	}
+}
+
+func TestSlicePoolConcurrent(t *testing.T) {
+	pool := goassist.NewSlicePool[int](8)
+	var wg sync.WaitGroup
+	for w := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				buf := pool.Get()
+				buf = append(buf, w, i)
+				if buf[0] != w || buf[1] != i {
+					t.Errorf("SlicePool failed: slice shared between goroutines")
+					return
+				}
+				pool.Put(buf)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkSlicePool(b *testing.B) {
+	pool := goassist.NewSlicePool[int](1000)
+	src := make([]int, 1000)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			buf := goassist.MapInto(pool.Get(), src, func(x int) int { return x + 1 })
+			pool.Put(buf)
+		}
+	})
+}
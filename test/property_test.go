@@ -0,0 +1,280 @@
+package goassist_test
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+	"testing/quick"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+// Property-based tests for the helpers in helper.go. testing/quick calls each property with
+// random arguments and reports the first input that makes it return false.
+
+func check(t *testing.T, property any) {
+	t.Helper()
+	if err := quick.Check(property, &quick.Config{MaxCount: 200}); err != nil {
+		t.Error(err)
+	}
+}
+
+// bounds turns two arbitrary ints into indices with 0 <= i <= j <= n.
+func bounds(n, a, b int) (int, int) {
+	if n == 0 {
+		return 0, 0
+	}
+	i, j := abs(a)%(n+1), abs(b)%(n+1)
+	return min(i, j), max(i, j)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func isEven(x int) bool { return x%2 == 0 }
+
+// isSubsequence reports whether sub appears in s in the same order.
+func isSubsequence(sub, s []int) bool {
+	for _, v := range s {
+		if len(sub) > 0 && sub[0] == v {
+			sub = sub[1:]
+		}
+	}
+	return len(sub) == 0
+}
+
+// sameElements reports whether a and b hold the same elements with the same counts.
+func sameElements(a, b []int) bool {
+	return slices.Equal(goassist.Sorted(a), goassist.Sorted(b))
+}
+
+func TestPropertyMapFilter(t *testing.T) {
+	check(t, func(x []int) bool {
+		doubled := goassist.Map(x, func(v int) int { return v * 2 })
+		if len(doubled) != len(x) {
+			return false
+		}
+		for i, v := range x {
+			if doubled[i] != v*2 {
+				return false
+			}
+		}
+		return true
+	})
+	check(t, func(x []int) bool {
+		even := goassist.Filter(x, isEven)
+		return goassist.Every(even, isEven) && isSubsequence(even, x) &&
+			len(even)+len(goassist.DeleteFunc(slices.Clone(x), isEven)) == len(x)
+	})
+	check(t, func(dst, x []int) bool {
+		want := append(slices.Clone(dst), goassist.Map(x, func(v int) int { return v + 1 })...)
+		return slices.Equal(goassist.MapInto(slices.Clone(dst), x, func(v int) int { return v + 1 }), want)
+	})
+	check(t, func(dst, x []int) bool {
+		want := append(slices.Clone(dst), goassist.Filter(x, isEven)...)
+		return slices.Equal(goassist.FilterInto(slices.Clone(dst), x, isEven), want)
+	})
+	check(t, func(x []int) bool {
+		return slices.Equal(goassist.FilterInPlace(slices.Clone(x), isEven), goassist.Filter(x, isEven))
+	})
+}
+
+func TestPropertyReduceFind(t *testing.T) {
+	check(t, func(x []int) bool {
+		sum := 0
+		for _, v := range x {
+			sum += v
+		}
+		return goassist.Reduce(x, func(acc, v int) int { return acc + v }, 0) == sum && goassist.Sum(x) == sum
+	})
+	check(t, func(x []int) bool {
+		v, found := goassist.Find(x, isEven)
+		i := goassist.IndexFunc(x, isEven)
+		if !found {
+			return i == -1 && !goassist.Some(x, isEven)
+		}
+		return x[i] == v && goassist.Some(x, isEven) && goassist.ContainsFunc(x, isEven)
+	})
+	check(t, func(x []int) bool {
+		return goassist.Every(x, isEven) == !goassist.Some(x, func(v int) bool { return !isEven(v) })
+	})
+}
+
+func TestPropertyFlatten(t *testing.T) {
+	check(t, func(x [][]int) bool {
+		flat := goassist.Flatten(x)
+		n := 0
+		for _, row := range x {
+			n += len(row)
+		}
+		deep, err := goassist.DeepFlatten[int](x)
+		return len(flat) == n && err == nil && slices.Equal(deep, flat)
+	})
+	check(t, func(x [][][]int) bool {
+		return slices.Equal(goassist.Flatten3(x), goassist.Flatten(goassist.Map(x, goassist.Flatten[int])))
+	})
+	check(t, func(x []int) bool {
+		pairs := func(v int) []int { return []int{v, -v} }
+		return slices.Equal(goassist.FlatMap(x, pairs), goassist.Flatten(goassist.Map(x, pairs)))
+	})
+}
+
+func TestPropertyZip(t *testing.T) {
+	check(t, func(a []int, b []string) bool {
+		pairs := goassist.Zip(a, b)
+		n := min(len(a), len(b))
+		first, second := goassist.Unzip[int, string](pairs)
+		return len(pairs) == n && slices.Equal(first, a[:n]) && slices.Equal(second, b[:n])
+	})
+}
+
+func TestPropertySearch(t *testing.T) {
+	check(t, func(x []int, target int) bool {
+		sorted := goassist.Sorted(x)
+		i, found := goassist.BinarySearch(sorted, target)
+		j, foundFunc := goassist.BinarySearchFunc(sorted, target, cmp.Compare[int])
+		if i != j || found != foundFunc || found != goassist.Contains(x, target) {
+			return false
+		}
+		// i is the insertion point: everything before it is smaller, everything after is not.
+		return !slices.ContainsFunc(sorted[:i], func(v int) bool { return v >= target }) &&
+			!slices.ContainsFunc(sorted[i:], func(v int) bool { return v < target })
+	})
+	check(t, func(x []int, v int) bool {
+		i := goassist.Index(x, v)
+		if i < 0 {
+			return !goassist.Contains(x, v)
+		}
+		return x[i] == v && !goassist.Contains(x[:i], v)
+	})
+}
+
+func TestPropertyCloneClipGrow(t *testing.T) {
+	check(t, func(x []int, n uint8) bool {
+		clone := goassist.Clone(x)
+		clipped := goassist.Clip(append(make([]int, 0, len(x)+5), x...))
+		grown := goassist.Grow(slices.Clone(x), int(n))
+		return goassist.Equal(clone, x) && cap(clipped) == len(x) && goassist.Equal(clipped, x) &&
+			cap(grown)-len(grown) >= int(n) && goassist.Equal(grown, x)
+	})
+}
+
+func TestPropertyCompact(t *testing.T) {
+	check(t, func(x []int) bool {
+		compact := goassist.Compact(slices.Clone(x))
+		for i := 1; i < len(compact); i++ {
+			if compact[i] == compact[i-1] {
+				return false
+			}
+		}
+		eq := func(a, b int) bool { return a == b }
+		return isSubsequence(compact, x) &&
+			slices.Equal(goassist.Compact(slices.Clone(compact)), compact) &&
+			slices.Equal(goassist.CompactFunc(slices.Clone(x), eq), compact) &&
+			slices.Equal(goassist.Compacted(x), compact)
+	})
+}
+
+func TestPropertyCompareEqual(t *testing.T) {
+	check(t, func(a, b []int) bool {
+		c := goassist.Compare(a, b)
+		return c == -goassist.Compare(b, a) &&
+			c == goassist.CompareFunc(a, b, cmp.Compare[int]) &&
+			(c == 0) == goassist.Equal(a, b) &&
+			goassist.Equal(a, b) == goassist.EqualFunc(a, b, func(x, y int) bool { return x == y }) &&
+			goassist.Compare(a, a) == 0
+	})
+}
+
+func TestPropertyDeleteInsertReplace(t *testing.T) {
+	check(t, func(x []int, a, b int) bool {
+		i, j := bounds(len(x), a, b)
+		want := append(slices.Clone(x[:i]), x[j:]...)
+		return slices.Equal(goassist.Delete(slices.Clone(x), i, j), want) &&
+			slices.Equal(goassist.WithDeleted(x, i, j), want)
+	})
+	check(t, func(x, v []int, a int) bool {
+		i, _ := bounds(len(x), a, a)
+		inserted := goassist.Insert(slices.Clone(x), i, v...)
+		return slices.Equal(goassist.Delete(slices.Clone(inserted), i, i+len(v)), x) &&
+			slices.Equal(inserted[i:i+len(v)], v) &&
+			slices.Equal(goassist.WithInserted(x, i, v...), inserted)
+	})
+	check(t, func(x, v []int, a, b int) bool {
+		i, j := bounds(len(x), a, b)
+		want := slices.Concat(x[:i], v, x[j:])
+		return slices.Equal(goassist.Replace(slices.Clone(x), i, j, v...), want)
+	})
+}
+
+func TestPropertyMinMax(t *testing.T) {
+	check(t, func(x []int64) bool {
+		if len(x) == 0 {
+			return true
+		}
+		lo, hi := goassist.Min(x), goassist.Max(x)
+		for _, v := range x {
+			if v < lo || v > hi {
+				return false
+			}
+		}
+		return slices.Contains(x, lo) && slices.Contains(x, hi) &&
+			goassist.MinFunc(x, cmp.Compare[int64]) == lo && goassist.MaxFunc(x, cmp.Compare[int64]) == hi
+	})
+	check(t, func(x []int) bool {
+		_, errMin := goassist.TryMin(x)
+		_, errMax := goassist.TryMax(x)
+		return (errMin == nil) == (len(x) > 0) && (errMax == nil) == (len(x) > 0)
+	})
+}
+
+func TestPropertyReverse(t *testing.T) {
+	check(t, func(x []int) bool {
+		r := slices.Clone(x)
+		goassist.Reverse(r)
+		for i, v := range x {
+			if r[len(r)-1-i] != v {
+				return false
+			}
+		}
+		goassist.Reverse(r)
+		return slices.Equal(r, x) && slices.Equal(goassist.Reversed(goassist.Reversed(x)), x)
+	})
+}
+
+func TestPropertySort(t *testing.T) {
+	check(t, func(x []int) bool {
+		original := slices.Clone(x)
+		sorted := goassist.Sorted(x)
+		byFunc := goassist.SortedFunc(x, cmp.Compare[int])
+		inPlace := slices.Clone(x)
+		goassist.Sort(inPlace)
+		return goassist.IsSorted(sorted) && goassist.IsSortedFunc(byFunc, cmp.Compare[int]) &&
+			sameElements(sorted, x) && slices.Equal(sorted, byFunc) && slices.Equal(sorted, inPlace) &&
+			slices.Equal(x, original)
+	})
+	check(t, func(x []int) bool {
+		descending := slices.Clone(x)
+		goassist.SortFunc(descending, func(a, b int) int { return cmp.Compare(b, a) })
+		return slices.Equal(descending, goassist.Reversed(goassist.Sorted(x)))
+	})
+	check(t, func(keys []uint8) bool {
+		// Sort positions by key mod 4: equal keys must keep their original order.
+		positions := make([]int, len(keys))
+		for i := range positions {
+			positions[i] = i
+		}
+		byKey := func(a, b int) int { return cmp.Compare(keys[a]%4, keys[b]%4) }
+		goassist.SortStableFunc(positions, byKey)
+		for i := 1; i < len(positions); i++ {
+			if byKey(positions[i-1], positions[i]) == 0 && positions[i-1] > positions[i] {
+				return false
+			}
+		}
+		return goassist.IsSortedFunc(positions, byKey)
+	})
+}
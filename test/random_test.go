@@ -0,0 +1,133 @@
+package goassist_test
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+func seeded() *rand.Rand {
+	return rand.New(rand.NewPCG(1, 2))
+}
+
+func TestShuffle(t *testing.T) {
+	deck := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	a, b := goassist.Clone(deck), goassist.Clone(deck)
+	goassist.Shuffle(a, seeded())
+	goassist.Shuffle(b, seeded())
+	if !slices.Equal(a, b) {
+		t.Errorf("Shuffle failed: expected same seed to give same order, got %v and %v", a, b)
+	}
+	goassist.Sort(a)
+	if !slices.Equal(a, deck) {
+		t.Errorf("Shuffle failed: expected a permutation of %v, got %v", deck, a)
+	}
+}
+
+func TestSample(t *testing.T) {
+	users := []string{"alice", "bob", "carol", "dave", "erin"}
+	original := goassist.Clone(users)
+	winners := goassist.Sample(users, 3, seeded())
+	if len(winners) != 3 {
+		t.Fatalf("Sample failed: expected 3 elements, got %v", winners)
+	}
+	if !slices.Equal(winners, goassist.Sample(users, 3, seeded())) {
+		t.Error("Sample failed: expected same seed to give same sample")
+	}
+	goassist.Sort(winners)
+	if len(goassist.Compact(winners)) != 3 {
+		t.Errorf("Sample failed: expected distinct elements, got %v", winners)
+	}
+	if !slices.Equal(users, original) {
+		t.Error("Sample failed: input should not be modified")
+	}
+	if all := goassist.Sample(users, 10, seeded()); len(all) != len(users) {
+		t.Errorf("Sample failed: expected %d elements, got %d", len(users), len(all))
+	}
+
+	counts := make([]int, 5)
+	r := seeded()
+	for range 10000 {
+		for _, v := range goassist.Sample([]int{0, 1, 2, 3, 4}, 2, r) {
+			counts[v]++
+		}
+	}
+	for i, c := range counts {
+		if c < 3600 || c > 4400 {
+			t.Errorf("Sample failed: expected element %d about 4000 times, got %d", i, c)
+		}
+	}
+}
+
+func TestReservoirSample(t *testing.T) {
+	counts := make([]int, 10)
+	r := seeded()
+	for range 10000 {
+		for _, v := range goassist.ReservoirSample(slices.Values([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}), 3, r) {
+			counts[v]++
+		}
+	}
+	for i, c := range counts {
+		if c < 2700 || c > 3300 {
+			t.Errorf("ReservoirSample failed: expected element %d about 3000 times, got %d", i, c)
+		}
+	}
+	if short := goassist.ReservoirSample(slices.Values([]int{1, 2}), 5, r); !slices.Equal(short, []int{1, 2}) {
+		t.Errorf("ReservoirSample failed: expected [1 2], got %v", short)
+	}
+}
+
+func TestWeightedChoice(t *testing.T) {
+	variants := []string{"control", "never", "treatment"}
+	weights := []float64{0.9, 0, 0.1}
+	r := seeded()
+	control := 0
+	for range 10000 {
+		v, err := goassist.WeightedChoice(variants, weights, r)
+		if err != nil || v == "never" {
+			t.Fatalf("WeightedChoice failed: got %q, %v", v, err)
+		}
+		if v == "control" {
+			control++
+		}
+	}
+	if control < 8800 || control > 9200 {
+		t.Errorf("WeightedChoice failed: expected control about 9000 times, got %d", control)
+	}
+
+	for _, bad := range [][]float64{{1}, {-1, 2}, {0, 0}, {math.NaN(), 1}} {
+		if _, err := goassist.WeightedChoice([]int{1, 2}, bad, r); !errors.Is(err, goassist.ErrInvalidWeights) {
+			t.Errorf("WeightedChoice failed: expected ErrInvalidWeights for %v, got %v", bad, err)
+		}
+	}
+}
+
+func TestWeightedSample(t *testing.T) {
+	weights := []float64{1, 2, 7, 0}
+	picks, err := goassist.WeightedSample([]int{0, 1, 2, 3}, weights, 20000, seeded())
+	if err != nil {
+		t.Fatalf("WeightedSample failed: %v", err)
+	}
+	counts := make([]int, 4)
+	for _, p := range picks {
+		counts[p]++
+	}
+	expected := []int{2000, 4000, 14000, 0}
+	for i, c := range counts {
+		if math.Abs(float64(c-expected[i])) > 400 {
+			t.Errorf("WeightedSample failed: expected index %d about %d times, got %d", i, expected[i], c)
+		}
+	}
+
+	again, _ := goassist.WeightedSample([]int{0, 1, 2, 3}, weights, 20000, seeded())
+	if !slices.Equal(picks, again) {
+		t.Error("WeightedSample failed: expected same seed to give same sample")
+	}
+	if _, err := goassist.WeightedSample([]int{1}, weights, 1, seeded()); !errors.Is(err, goassist.ErrInvalidWeights) {
+		t.Errorf("WeightedSample failed: expected ErrInvalidWeights, got %v", err)
+	}
+}
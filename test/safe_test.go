@@ -0,0 +1,96 @@
+package goassist_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+func TestTryDelete(t *testing.T) {
+	got, err := goassist.TryDelete([]int{1, 2, 3, 4, 5}, 1, 3)
+	if err != nil || !slices.Equal(got, []int{1, 4, 5}) {
+		t.Errorf("TryDelete failed: got %v, %v", got, err)
+	}
+
+	numbers := []int{1, 2, 3}
+	for _, c := range [][2]int{{1, 5}, {-1, 2}, {2, 1}} {
+		got, err := goassist.TryDelete(numbers, c[0], c[1])
+		var indexErr *goassist.IndexError
+		if !errors.As(err, &indexErr) || !errors.Is(err, goassist.ErrIndexOutOfRange) {
+			t.Fatalf("TryDelete(%v) failed: expected *IndexError, got %v", c, err)
+		}
+		if indexErr.Op != "TryDelete" || indexErr.Start != c[0] || indexErr.End != c[1] || indexErr.Len != 3 {
+			t.Errorf("TryDelete(%v) failed: unexpected error fields %+v", c, *indexErr)
+		}
+		if !slices.Equal(got, numbers) {
+			t.Errorf("TryDelete(%v) failed: expected input back, got %v", c, got)
+		}
+	}
+}
+
+func TestTryInsertReplace(t *testing.T) {
+	got, err := goassist.TryInsert([]int{1, 2, 5}, 2, 3, 4)
+	if err != nil || !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("TryInsert failed: got %v, %v", got, err)
+	}
+	if got, err := goassist.TryInsert([]int{1}, 1, 2); err != nil || !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("TryInsert failed: expected append at the end, got %v, %v", got, err)
+	}
+	_, err = goassist.TryInsert([]int{1, 2}, 3, 9)
+	if !errors.Is(err, goassist.ErrIndexOutOfRange) || err.Error() != "goassist: index out of range: TryInsert index 3 with length 2" {
+		t.Errorf("TryInsert failed: got %v", err)
+	}
+
+	got, err = goassist.TryReplace([]int{1, 2, 3, 4, 5}, 1, 4, 6, 7)
+	if err != nil || !slices.Equal(got, []int{1, 6, 7, 5}) {
+		t.Errorf("TryReplace failed: got %v, %v", got, err)
+	}
+	_, err = goassist.TryReplace([]int{1, 2}, 1, 4, 0)
+	if !errors.Is(err, goassist.ErrIndexOutOfRange) || err.Error() != "goassist: index out of range: TryReplace range [1:4] with length 2" {
+		t.Errorf("TryReplace failed: got %v", err)
+	}
+}
+
+func TestTryMinMax(t *testing.T) {
+	if v, err := goassist.TryMin([]int{4, 2, 5}); v != 2 || err != nil {
+		t.Errorf("TryMin failed: got %v, %v", v, err)
+	}
+	if v, err := goassist.TryMax([]int{4, 2, 5}); v != 5 || err != nil {
+		t.Errorf("TryMax failed: got %v, %v", v, err)
+	}
+
+	_, err := goassist.TryMin([]float64{})
+	var emptyErr *goassist.EmptyError
+	if !errors.As(err, &emptyErr) || emptyErr.Op != "TryMin" || !errors.Is(err, goassist.ErrEmpty) {
+		t.Errorf("TryMin failed: expected *EmptyError, got %v", err)
+	}
+	if _, err := goassist.TryMax([]string(nil)); !errors.Is(err, goassist.ErrEmpty) {
+		t.Errorf("TryMax failed: expected ErrEmpty, got %v", err)
+	}
+}
+
+func TestSlice(t *testing.T) {
+	numbers := []int{1, 2, 3, 4, 5}
+	cases := []struct {
+		start, end int
+		expected   []int
+	}{
+		{1, 3, []int{2, 3}},
+		{-2, 5, []int{4, 5}},
+		{0, 10, []int{1, 2, 3, 4, 5}},
+		{-10, 2, []int{1, 2}},
+		{0, -1, []int{1, 2, 3, 4}},
+		{4, 2, []int{}},
+		{7, 9, []int{}},
+	}
+	for _, c := range cases {
+		if got := goassist.Slice(numbers, c.start, c.end); !slices.Equal(got, c.expected) {
+			t.Errorf("Slice(%d, %d) failed: expected %v, got %v", c.start, c.end, c.expected, got)
+		}
+	}
+	if got := goassist.Slice([]int(nil), -1, 1); len(got) != 0 {
+		t.Errorf("Slice failed: expected empty slice, got %v", got)
+	}
+}
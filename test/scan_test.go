@@ -0,0 +1,89 @@
+package goassist_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+func TestScan(t *testing.T) {
+	deposits := []int{10, -5, 20}
+	add := func(acc, x int) int { return acc + x }
+
+	balances := goassist.Scan(deposits, add, 100)
+	if expected := []int{110, 105, 125}; !slices.Equal(balances, expected) {
+		t.Errorf("Scan failed: expected %v, got %v", expected, balances)
+	}
+	if last := balances[len(balances)-1]; last != goassist.Reduce(deposits, add, 100) {
+		t.Errorf("Scan failed: expected last element to match Reduce, got %d", last)
+	}
+
+	withInitial := goassist.ScanLeft(deposits, add, 100)
+	if expected := []int{100, 110, 105, 125}; !slices.Equal(withInitial, expected) {
+		t.Errorf("ScanLeft failed: expected %v, got %v", expected, withInitial)
+	}
+	if empty := goassist.ScanLeft([]int{}, add, 7); !slices.Equal(empty, []int{7}) {
+		t.Errorf("ScanLeft failed: expected [7], got %v", empty)
+	}
+}
+
+func TestPrefixSum(t *testing.T) {
+	totals := goassist.PrefixSum([]float64{0.5, 1.5, 2})
+	if expected := []float64{0.5, 2, 4}; !slices.Equal(totals, expected) {
+		t.Errorf("PrefixSum failed: expected %v, got %v", expected, totals)
+	}
+}
+
+func TestRunningMinMax(t *testing.T) {
+	prices := []int{5, 3, 4, 1, 2, 6}
+	if lows := goassist.RunningMin(prices); !slices.Equal(lows, []int{5, 3, 3, 1, 1, 1}) {
+		t.Errorf("RunningMin failed: expected [5 3 3 1 1 1], got %v", lows)
+	}
+	if highs := goassist.RunningMax(prices); !slices.Equal(highs, []int{5, 5, 5, 5, 5, 6}) {
+		t.Errorf("RunningMax failed: expected [5 5 5 5 5 6], got %v", highs)
+	}
+}
+
+func TestPrefixSums(t *testing.T) {
+	sales := goassist.NewPrefixSums([]int{3, 1, 4, 1, 5})
+	if week := sales.RangeSum(1, 4); week != 6 {
+		t.Errorf("PrefixSums failed: expected 6, got %d", week)
+	}
+	if all := sales.RangeSum(0, sales.Len()); all != 14 {
+		t.Errorf("PrefixSums failed: expected 14, got %d", all)
+	}
+	if none := sales.RangeSum(2, 2); none != 0 {
+		t.Errorf("PrefixSums failed: expected 0, got %d", none)
+	}
+}
+
+func TestFenwickTree(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 5))
+	values := make([]int, 100)
+	for i := range values {
+		values[i] = r.IntN(100)
+	}
+	tree := goassist.FenwickTreeFromSlice(values)
+
+	for range 500 {
+		i := r.IntN(len(values))
+		if r.IntN(2) == 0 {
+			delta := r.IntN(21) - 10
+			values[i] += delta
+			tree.Add(i, delta)
+		} else {
+			v := r.IntN(100)
+			values[i] = v
+			tree.Set(i, v)
+		}
+
+		lo := r.IntN(len(values))
+		hi := lo + r.IntN(len(values)-lo+1)
+		expected := goassist.Reduce(values[lo:hi], func(acc, x int) int { return acc + x }, 0)
+		if got := tree.RangeSum(lo, hi); got != expected {
+			t.Fatalf("FenwickTree failed: expected RangeSum(%d, %d) = %d, got %d", lo, hi, expected, got)
+		}
+	}
+}
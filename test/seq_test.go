@@ -0,0 +1,167 @@
+package goassist_test
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+func TestMapSeq(t *testing.T) {
+	numbers := []int{1, 2, 3}
+	doubled := slices.Collect(goassist.MapSeq(slices.Values(numbers), func(x int) int {
+		return x * 2
+	}))
+	expected := []int{2, 4, 6}
+	if !slices.Equal(doubled, expected) {
+		t.Errorf("MapSeq failed: expected %v, got %v", expected, doubled)
+	}
+}
+
+func TestFilterSeq(t *testing.T) {
+	numbers := []int{1, 2, 3, 4, 5}
+	evens := slices.Collect(goassist.FilterSeq(slices.Values(numbers), func(x int) bool {
+		return x%2 == 0
+	}))
+	expected := []int{2, 4}
+	if !slices.Equal(evens, expected) {
+		t.Errorf("FilterSeq failed: expected %v, got %v", expected, evens)
+	}
+}
+
+func TestReduceSeq(t *testing.T) {
+	m := map[string]int{"alice": 25, "bob": 30}
+	total := goassist.ReduceSeq(maps.Values(m), func(acc, x int) int {
+		return acc + x
+	}, 0)
+	if total != 55 {
+		t.Errorf("ReduceSeq failed: expected 55, got %d", total)
+	}
+}
+
+func TestFindSeq(t *testing.T) {
+	pulled := 0
+	seq := func(yield func(int) bool) {
+		for _, v := range []int{1, 2, 3, 4, 5} {
+			pulled++
+			if !yield(v) {
+				return
+			}
+		}
+	}
+	first, found := goassist.FindSeq(seq, func(x int) bool {
+		return x > 3
+	})
+	if !found || first != 4 {
+		t.Errorf("FindSeq failed: expected 4, got %d, found %v", first, found)
+	}
+	if pulled != 4 {
+		t.Errorf("FindSeq failed: expected 4 elements pulled, got %d", pulled)
+	}
+	_, found = goassist.FindSeq(seq, func(x int) bool {
+		return x > 10
+	})
+	if found {
+		t.Error("FindSeq failed: expected false, got true")
+	}
+}
+
+func TestSomeSeq(t *testing.T) {
+	m := map[string]int{"alice": 25, "bob": 30}
+	if !goassist.SomeSeq(maps.Values(m), func(age int) bool { return age >= 30 }) {
+		t.Error("SomeSeq failed: expected true, got false")
+	}
+	if goassist.SomeSeq(maps.Values(m), func(age int) bool { return age < 18 }) {
+		t.Error("SomeSeq failed: expected false, got true")
+	}
+}
+
+func TestEverySeq(t *testing.T) {
+	m := map[string]int{"alice": 25, "bob": 30}
+	if !goassist.EverySeq(maps.Values(m), func(age int) bool { return age >= 18 }) {
+		t.Error("EverySeq failed: expected true, got false")
+	}
+	if goassist.EverySeq(maps.Values(m), func(age int) bool { return age >= 30 }) {
+		t.Error("EverySeq failed: expected false, got true")
+	}
+}
+
+func TestContainsSeq(t *testing.T) {
+	m := map[string]int{"alice": 25, "bob": 30}
+	if !goassist.ContainsSeq(maps.Keys(m), "bob") {
+		t.Error("ContainsSeq failed: expected true, got false")
+	}
+	if goassist.ContainsSeq(maps.Keys(m), "carol") {
+		t.Error("ContainsSeq failed: expected false, got true")
+	}
+	if !goassist.ContainsFuncSeq(maps.Keys(m), func(s string) bool { return strings.HasPrefix(s, "al") }) {
+		t.Error("ContainsFuncSeq failed: expected true, got false")
+	}
+}
+
+func TestIndexSeq(t *testing.T) {
+	numbers := []int{1, 2, 3, 2, 4}
+	if index := goassist.IndexSeq(slices.Values(numbers), 2); index != 1 {
+		t.Errorf("IndexSeq failed: expected 1, got %d", index)
+	}
+	if index := goassist.IndexSeq(slices.Values(numbers), 7); index != -1 {
+		t.Errorf("IndexSeq failed: expected -1, got %d", index)
+	}
+	index := goassist.IndexFuncSeq(slices.Values(numbers), func(x int) bool {
+		return x > 2
+	})
+	if index != 2 {
+		t.Errorf("IndexFuncSeq failed: expected 2, got %d", index)
+	}
+}
+
+func TestMinMaxSeq(t *testing.T) {
+	m := map[string]int{"alice": 25, "bob": 30, "carol": 19}
+	if youngest := goassist.MinSeq(maps.Values(m)); youngest != 19 {
+		t.Errorf("MinSeq failed: expected 19, got %d", youngest)
+	}
+	if oldest := goassist.MaxSeq(maps.Values(m)); oldest != 30 {
+		t.Errorf("MaxSeq failed: expected 30, got %d", oldest)
+	}
+
+	type Person struct {
+		Name string
+		Age  int
+	}
+	people := []Person{{"Alice", 25}, {"Bob", 30}, {"Carol", 30}}
+	byAge := func(a, b Person) int { return a.Age - b.Age }
+	if p := goassist.MaxFuncSeq(slices.Values(people), byAge); p.Name != "Bob" {
+		t.Errorf("MaxFuncSeq failed: expected Bob, got %s", p.Name)
+	}
+	if p := goassist.MinFuncSeq(slices.Values(people), byAge); p.Name != "Alice" {
+		t.Errorf("MinFuncSeq failed: expected Alice, got %s", p.Name)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MinSeq failed: expected panic on empty sequence")
+		}
+	}()
+	goassist.MinSeq(slices.Values([]int{}))
+}
+
+func TestSortedCollect(t *testing.T) {
+	m := map[string]int{"charlie": 35, "alice": 25, "bob": 30}
+	names := goassist.SortedCollect(maps.Keys(m))
+	expected := []string{"alice", "bob", "charlie"}
+	if !slices.Equal(names, expected) {
+		t.Errorf("SortedCollect failed: expected %v, got %v", expected, names)
+	}
+	if index, found := goassist.BinarySearch(names, "bob"); !found || index != 1 {
+		t.Errorf("SortedCollect failed: expected bob at 1, got %d, found %v", index, found)
+	}
+
+	ages := goassist.SortedCollectFunc(maps.Values(m), func(a, b int) int {
+		return b - a
+	})
+	if !slices.Equal(ages, []int{35, 30, 25}) {
+		t.Errorf("SortedCollectFunc failed: expected [35 30 25], got %v", ages)
+	}
+}
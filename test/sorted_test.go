@@ -0,0 +1,125 @@
+package goassist_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+func TestLowerUpperBound(t *testing.T) {
+	numbers := []int{1, 3, 3, 5}
+	cases := []struct{ target, lower, upper int }{
+		{0, 0, 0}, {1, 0, 1}, {3, 1, 3}, {4, 3, 3}, {5, 3, 4}, {9, 4, 4},
+	}
+	for _, c := range cases {
+		if got := goassist.LowerBound(numbers, c.target); got != c.lower {
+			t.Errorf("LowerBound(%d) failed: expected %d, got %d", c.target, c.lower, got)
+		}
+		if got := goassist.UpperBound(numbers, c.target); got != c.upper {
+			t.Errorf("UpperBound(%d) failed: expected %d, got %d", c.target, c.upper, got)
+		}
+	}
+
+	type Person struct {
+		Name string
+		Age  int
+	}
+	people := []Person{{"Alice", 25}, {"Bob", 30}, {"Carol", 30}, {"Dave", 35}}
+	byAge := func(p Person, age int) int { return p.Age - age }
+	if lo, hi := goassist.LowerBoundFunc(people, 30, byAge), goassist.UpperBoundFunc(people, 30, byAge); lo != 1 || hi != 3 {
+		t.Errorf("LowerBoundFunc/UpperBoundFunc failed: expected [1, 3), got [%d, %d)", lo, hi)
+	}
+}
+
+func TestInsertSorted(t *testing.T) {
+	numbers := []int{}
+	for _, v := range []int{5, 1, 4, 1, 3} {
+		numbers = goassist.InsertSorted(numbers, v)
+	}
+	if !slices.Equal(numbers, []int{1, 1, 3, 4, 5}) {
+		t.Errorf("InsertSorted failed: expected [1 1 3 4 5], got %v", numbers)
+	}
+
+	words := []string{"apple", "Cherry"}
+	words = goassist.InsertSortedFunc(words, "banana", func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	if !slices.Equal(words, []string{"apple", "banana", "Cherry"}) {
+		t.Errorf("InsertSortedFunc failed: expected [apple banana Cherry], got %v", words)
+	}
+}
+
+func TestRemoveSorted(t *testing.T) {
+	numbers, removed := goassist.RemoveSorted([]int{1, 3, 3, 5}, 3)
+	if !removed || !slices.Equal(numbers, []int{1, 3, 5}) {
+		t.Errorf("RemoveSorted failed: expected [1 3 5], got %v, %v", numbers, removed)
+	}
+	if _, removed = goassist.RemoveSorted(numbers, 4); removed {
+		t.Error("RemoveSorted failed: expected missing value not to be removed")
+	}
+	words, removed := goassist.RemoveSortedFunc([]string{"a", "bb", "ccc"}, 2, func(s string, n int) int {
+		return len(s) - n
+	})
+	if !removed || !slices.Equal(words, []string{"a", "ccc"}) {
+		t.Errorf("RemoveSortedFunc failed: expected [a ccc], got %v, %v", words, removed)
+	}
+}
+
+func TestRangeSorted(t *testing.T) {
+	scores := []int{10, 20, 20, 30, 40, 50}
+	if passing := goassist.RangeSorted(scores, 20, 40); !slices.Equal(passing, []int{20, 20, 30}) {
+		t.Errorf("RangeSorted failed: expected [20 20 30], got %v", passing)
+	}
+	if empty := goassist.RangeSorted(scores, 40, 20); len(empty) != 0 {
+		t.Errorf("RangeSorted failed: expected empty range, got %v", empty)
+	}
+	lengths := goassist.RangeSortedFunc([]string{"a", "bb", "cc", "ddd"}, 2, 3, func(s string, n int) int {
+		return len(s) - n
+	})
+	if !slices.Equal(lengths, []string{"bb", "cc"}) {
+		t.Errorf("RangeSortedFunc failed: expected [bb cc], got %v", lengths)
+	}
+}
+
+func TestMergeSorted(t *testing.T) {
+	merged := goassist.MergeSorted([]int{1, 4, 7}, []int{}, []int{2, 5}, []int{3, 6, 8, 9})
+	if !slices.Equal(merged, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("MergeSorted failed: expected [1 ... 9], got %v", merged)
+	}
+
+	type item struct {
+		key    int
+		source string
+	}
+	stable := goassist.MergeSortedFunc(func(a, b item) int { return a.key - b.key },
+		[]item{{1, "a"}, {2, "a"}}, []item{{1, "b"}, {2, "b"}})
+	sources := goassist.Map(stable, func(i item) string { return i.source })
+	if !slices.Equal(sources, []string{"a", "b", "a", "b"}) {
+		t.Errorf("MergeSortedFunc failed: expected stable order [a b a b], got %v", sources)
+	}
+}
+
+func TestIntersectUnionSorted(t *testing.T) {
+	a := []int{1, 2, 2, 3, 5}
+	b := []int{2, 2, 4, 5, 6}
+	if common := goassist.IntersectSorted(a, b); !slices.Equal(common, []int{2, 2, 5}) {
+		t.Errorf("IntersectSorted failed: expected [2 2 5], got %v", common)
+	}
+	all := goassist.UnionSorted(a, b)
+	if !slices.Equal(all, []int{1, 2, 2, 3, 4, 5, 6}) {
+		t.Errorf("UnionSorted failed: expected [1 2 2 3 4 5 6], got %v", all)
+	}
+	if !goassist.IsSorted(all) {
+		t.Error("UnionSorted failed: expected sorted result")
+	}
+
+	desc := func(a, b int) int { return b - a }
+	if common := goassist.IntersectSortedFunc([]int{5, 3, 1}, []int{4, 3, 1}, desc); !slices.Equal(common, []int{3, 1}) {
+		t.Errorf("IntersectSortedFunc failed: expected [3 1], got %v", common)
+	}
+	if all := goassist.UnionSortedFunc([]int{5, 1}, []int{4, 1}, desc); !slices.Equal(all, []int{5, 4, 1}) {
+		t.Errorf("UnionSortedFunc failed: expected [5 4 1], got %v", all)
+	}
+}
@@ -0,0 +1,127 @@
+package goassist_test
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+type errorAfterReader struct {
+	data string
+	err  error
+}
+
+func (r *errorAfterReader) Read(p []byte) (int, error) {
+	if r.data == "" {
+		return 0, r.err
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestLines(t *testing.T) {
+	long := strings.Repeat("x", 100000)
+	input := "first\r\nsecond\n\n" + long + "\nlast"
+	var err error
+	got := slices.Collect(goassist.UntilError(goassist.Lines(strings.NewReader(input)), &err))
+	expected := []string{"first", "second", "", long, "last"}
+	if err != nil || !slices.Equal(got, expected) {
+		t.Errorf("Lines failed: expected %d lines, got %d, %v", len(expected), len(got), err)
+	}
+
+	if empty := slices.Collect(goassist.UntilError(goassist.Lines(strings.NewReader("")), &err)); len(empty) != 0 {
+		t.Errorf("Lines failed: expected no lines, got %v", empty)
+	}
+
+	failing := &errorAfterReader{data: "a\nb\n", err: io.ErrClosedPipe}
+	got = slices.Collect(goassist.UntilError(goassist.Lines(failing), &err))
+	if !slices.Equal(got, []string{"a", "b"}) || !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("Lines failed: expected [a b] and an error, got %v, %v", got, err)
+	}
+}
+
+func TestCSVRecords(t *testing.T) {
+	input := "id,name,status\n1,Alice,paid\n2,\"Bob, Jr.\",unpaid\n3,Carol,paid\n"
+	var err error
+	records := goassist.UntilError(goassist.CSVRecords(strings.NewReader(input)), &err)
+	paid := goassist.FilterSeq(records, func(record []string) bool { return record[2] != "unpaid" })
+
+	var sb strings.Builder
+	if werr := goassist.WriteCSVRecords(&sb, paid); werr != nil || err != nil {
+		t.Fatalf("WriteCSVRecords failed: %v, %v", werr, err)
+	}
+	if expected := "id,name,status\n1,Alice,paid\n3,Carol,paid\n"; sb.String() != expected {
+		t.Errorf("CSVRecords failed: expected %q, got %q", expected, sb.String())
+	}
+
+	names := []string{}
+	for record, err := range goassist.CSVRecords(strings.NewReader(input)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, record[1])
+	}
+	if expected := []string{"name", "Alice", "Bob, Jr.", "Carol"}; !slices.Equal(names, expected) {
+		t.Errorf("CSVRecords failed: expected %v, got %v", expected, names)
+	}
+
+	collected := slices.Collect(goassist.UntilError(goassist.CSVRecords(strings.NewReader("a,b\nc\n")), &err))
+	if len(collected) != 1 || err == nil {
+		t.Errorf("CSVRecords failed: expected 1 record and an error, got %d, %v", len(collected), err)
+	}
+}
+
+func TestNDJSON(t *testing.T) {
+	input := "{\"id\":1,\"amount\":5}\n\n{\"id\":2,\"amount\":7}\n"
+	var err error
+	orders := slices.Collect(goassist.UntilError(goassist.NDJSON[order](strings.NewReader(input)), &err))
+	if err != nil || !slices.Equal(orders, []order{{1, 5}, {2, 7}}) {
+		t.Errorf("NDJSON failed: got %v, %v", orders, err)
+	}
+
+	var sb strings.Builder
+	goassist.EncodeJSONSeq(&sb, slices.Values(orders), goassist.JSONLines)
+	again := slices.Collect(goassist.UntilError(goassist.NDJSON[order](strings.NewReader(sb.String())), &err))
+	if !slices.Equal(again, orders) {
+		t.Errorf("NDJSON round trip failed: expected %v, got %v", orders, again)
+	}
+
+	bad := slices.Collect(goassist.UntilError(goassist.NDJSON[order](strings.NewReader("{\"id\":1}\nnot json\n")), &err))
+	if len(bad) != 1 || err == nil {
+		t.Errorf("NDJSON failed: expected 1 value and an error, got %v, %v", bad, err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("NDJSON failed: expected the error to name line 2, got %v", err)
+	}
+
+	for _, input := range []string{"{\"id\":1} {\"id\":2}\n", "{\"id\":\n1}\n"} {
+		values := slices.Collect(goassist.UntilError(goassist.NDJSON[order](strings.NewReader(input)), &err))
+		if len(values) != 0 || err == nil {
+			t.Errorf("NDJSON failed: expected an error for %q, got %v, %v", input, values, err)
+		}
+	}
+}
+
+func TestWriteLines(t *testing.T) {
+	var err error
+	lines := goassist.UntilError(goassist.Lines(strings.NewReader("info: ok\nerror: disk\nerror: net\n")), &err)
+	errorsOnly := goassist.FilterSeq(lines, func(line string) bool { return strings.HasPrefix(line, "error") })
+
+	var sb strings.Builder
+	if werr := goassist.WriteLines(&sb, goassist.MapSeq(errorsOnly, strings.ToUpper)); werr != nil || err != nil {
+		t.Fatalf("WriteLines failed: %v, %v", werr, err)
+	}
+	if expected := "ERROR: DISK\nERROR: NET\n"; sb.String() != expected {
+		t.Errorf("WriteLines failed: expected %q, got %q", expected, sb.String())
+	}
+
+	many := slices.Values(slices.Repeat([]string{"line"}, 10000))
+	if werr := goassist.WriteLines(failingWriter{}, many); !errors.Is(werr, io.ErrClosedPipe) {
+		t.Errorf("WriteLines failed: expected write error, got %v", werr)
+	}
+}
@@ -0,0 +1,125 @@
+package goassist_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+type ticket struct {
+	ID    int
+	Title string
+	Body  string
+}
+
+func ticketIDs(tickets []ticket) []int {
+	return goassist.Map(tickets, func(t ticket) int { return t.ID })
+}
+
+func newTicketIndex() *goassist.TextIndex[ticket] {
+	tickets := []ticket{
+		{1, "Login timeout", "The login page times out on mobile."},
+		{2, "Login button misaligned", "On the desktop site the login button is misaligned."},
+		{3, "Timeout in reports", "Exporting reports hits a timeout. Timeout after 30s, timeout again."},
+		{4, "Password reset email", "Reset emails are not delivered to the user."},
+		{5, "Logging is too verbose", "Debug logging fills the disk."},
+	}
+	return goassist.NewTextIndex(tickets, func(t ticket) string {
+		return t.Title + " " + t.Body
+	}, goassist.TextIndexOptions{})
+}
+
+func TestTextIndexSearch(t *testing.T) {
+	index := newTicketIndex()
+	if index.Len() != 5 {
+		t.Errorf("TextIndex failed: expected 5 documents, got %d", index.Len())
+	}
+
+	cases := []struct {
+		query    string
+		expected []int
+	}{
+		{"login", []int{1, 2}},
+		{"LOGIN timeout", []int{1}},
+		{"login AND timeout", []int{1}},
+		{"timeout", []int{3, 1}},
+		{"login -mobile", []int{2}},
+		{"login NOT mobile", []int{2}},
+		{"log*", []int{5, 1, 2}},
+		{"reset OR verbose", []int{4, 5}},
+		{"password OR login mobile", []int{1, 4}},
+		{"the", []int{}},
+		{"-login", []int{}},
+		{"missing", []int{}},
+	}
+	for _, c := range cases {
+		if got := ticketIDs(index.Search(c.query)); !slices.Equal(got, c.expected) {
+			t.Errorf("TextIndex.Search(%q) failed: expected %v, got %v", c.query, c.expected, got)
+		}
+	}
+}
+
+func TestTextIndexStopWordPrefix(t *testing.T) {
+	docs := []string{"rebuild the search index", "invoice overdue", "login fails", "login index broken"}
+	index := goassist.NewTextIndex(docs, strings.Clone, goassist.TextIndexOptions{})
+
+	// "in" is a stop word, but "in*" is a prefix and must still match "index" and "invoice".
+	got := index.Search("in*")
+	slices.Sort(got)
+	if expected := []string{"invoice overdue", "login index broken", "rebuild the search index"}; !slices.Equal(got, expected) {
+		t.Errorf("TextIndex.Search(%q) failed: expected %q, got %q", "in*", expected, got)
+	}
+	if got := index.Search("login in*"); !slices.Equal(got, []string{"login index broken"}) {
+		t.Errorf("TextIndex.Search(%q) failed: expected the prefix term to be kept, got %q", "login in*", got)
+	}
+	if got := index.Search("login in"); len(got) != 2 {
+		t.Errorf("TextIndex.Search(%q) failed: expected the exact stop word to be dropped, got %q", "login in", got)
+	}
+}
+
+func TestTextIndexDisableLengthNorm(t *testing.T) {
+	docs := []string{"login error in a long report about many other unrelated things", "login error"}
+	hits := goassist.NewTextIndex(docs, strings.Clone, goassist.TextIndexOptions{}).SearchHits("login")
+	if len(hits) != 2 || hits[0].Index != 1 || hits[0].Score <= hits[1].Score {
+		t.Errorf("TextIndex.SearchHits failed: expected the short document to rank first, got %v", hits)
+	}
+
+	flat := goassist.NewTextIndex(docs, strings.Clone, goassist.TextIndexOptions{B: 0.9, DisableLengthNorm: true})
+	hits = flat.SearchHits("login")
+	if len(hits) != 2 || hits[0].Index != 0 || hits[0].Score != hits[1].Score {
+		t.Errorf("TextIndex.SearchHits failed: expected equal scores in original order without length normalization, got %v", hits)
+	}
+}
+
+func TestTextIndexSearchHits(t *testing.T) {
+	hits := newTicketIndex().SearchHits("timeout")
+	if len(hits) != 2 || hits[0].Index != 2 || hits[1].Index != 0 {
+		t.Fatalf("TextIndex.SearchHits failed: got %v", hits)
+	}
+	if hits[0].Score <= hits[1].Score || hits[1].Score <= 0 {
+		t.Errorf("TextIndex.SearchHits failed: expected decreasing positive scores, got %v and %v", hits[0].Score, hits[1].Score)
+	}
+}
+
+func TestTextIndexOptions(t *testing.T) {
+	docs := []string{"The Go programming language", "the end"}
+	index := goassist.NewTextIndex(docs, strings.Clone, goassist.TextIndexOptions{StopWords: []string{}})
+	if got := index.Search("the"); len(got) != 2 {
+		t.Errorf("TextIndex failed: expected stop words to be indexed, got %v", got)
+	}
+
+	custom := goassist.NewTextIndex(docs, strings.Clone, goassist.TextIndexOptions{StopWords: []string{"Go"}})
+	if got := custom.Search("go"); len(got) != 0 {
+		t.Errorf("TextIndex failed: expected custom stop word to be skipped, got %v", got)
+	}
+	if got := custom.Search("the"); len(got) != 2 {
+		t.Errorf("TextIndex failed: expected default stop words to be replaced, got %v", got)
+	}
+
+	empty := goassist.NewTextIndex([]string{}, strings.Clone, goassist.TextIndexOptions{})
+	if got := empty.Search("anything"); len(got) != 0 {
+		t.Errorf("TextIndex failed: expected no hits, got %v", got)
+	}
+}
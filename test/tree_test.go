@@ -0,0 +1,105 @@
+package goassist_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+type category struct {
+	ID       int
+	ParentID int
+	Name     string
+}
+
+func categoryTree() []*goassist.Node[category] {
+	rows := []category{
+		{ID: 2, ParentID: 1, Name: "Phones"},
+		{ID: 1, ParentID: 0, Name: "Electronics"},
+		{ID: 3, ParentID: 1, Name: "Laptops"},
+		{ID: 4, ParentID: 0, Name: "Books"},
+		{ID: 5, ParentID: 2, Name: "Smartphones"},
+		{ID: 6, ParentID: 7, Name: "Loop A"},
+		{ID: 7, ParentID: 6, Name: "Loop B"},
+	}
+	return goassist.BuildTree(rows, func(c category) int {
+		return c.ID
+	}, func(c category) int {
+		return c.ParentID
+	})
+}
+
+func treeNames(roots []*goassist.Node[category]) []string {
+	names := []string{}
+	for depth, n := range goassist.WalkDepthFirst(roots) {
+		names = append(names, strings.Repeat("-", depth)+n.Value.Name)
+	}
+	return names
+}
+
+func TestBuildTree(t *testing.T) {
+	roots := categoryTree()
+	names := treeNames(roots)
+	expected := []string{"Electronics", "-Phones", "--Smartphones", "-Laptops", "Books"}
+	if !slices.Equal(names, expected) {
+		t.Errorf("BuildTree failed: expected %v, got %v", expected, names)
+	}
+}
+
+func TestWalkBreadthFirst(t *testing.T) {
+	names := []string{}
+	for depth, n := range goassist.WalkBreadthFirst(categoryTree()) {
+		names = append(names, strings.Repeat("-", depth)+n.Value.Name)
+		if n.Value.Name == "Laptops" {
+			break
+		}
+	}
+	expected := []string{"Electronics", "Books", "-Phones", "-Laptops"}
+	if !slices.Equal(names, expected) {
+		t.Errorf("WalkBreadthFirst failed: expected %v, got %v", expected, names)
+	}
+}
+
+func TestMapTree(t *testing.T) {
+	lengths := goassist.MapTree(categoryTree(), func(c category) int {
+		return len(c.Name)
+	})
+	if lengths[0].Value != 11 || lengths[0].Children[0].Children[0].Value != 11 || lengths[1].Value != 5 {
+		t.Errorf("MapTree failed: unexpected values %v", goassist.FlattenTree(lengths))
+	}
+}
+
+func TestFilterTree(t *testing.T) {
+	roots := categoryTree()
+	found := goassist.FilterTree(roots, func(c category) bool {
+		return strings.HasPrefix(c.Name, "Smart")
+	})
+	expected := []string{"Electronics", "-Phones", "--Smartphones"}
+	if names := treeNames(found); !slices.Equal(names, expected) {
+		t.Errorf("FilterTree failed: expected %v, got %v", expected, names)
+	}
+	if len(roots[0].Children) != 2 {
+		t.Error("FilterTree failed: original tree should not be modified")
+	}
+}
+
+func TestFlattenTree(t *testing.T) {
+	flat := goassist.FlattenTree(categoryTree())
+	expected := []goassist.FlatNode[string]{
+		{Value: "Electronics", Depth: 0, Parent: -1},
+		{Value: "Phones", Depth: 1, Parent: 0},
+		{Value: "Smartphones", Depth: 2, Parent: 1},
+		{Value: "Laptops", Depth: 1, Parent: 0},
+		{Value: "Books", Depth: 0, Parent: -1},
+	}
+	if len(flat) != len(expected) {
+		t.Fatalf("FlattenTree failed: expected %d nodes, got %d", len(expected), len(flat))
+	}
+	for i, n := range flat {
+		if n.Value.Name != expected[i].Value || n.Depth != expected[i].Depth || n.Parent != expected[i].Parent {
+			t.Errorf("FlattenTree failed: expected %v, got %v", expected[i], n)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package goassist_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	goassist "github.com/fobus1289/go_assist"
+)
+
+func TestTrie(t *testing.T) {
+	trie := goassist.NewTrie[int]()
+	trie.Insert("car", 1)
+	trie.Insert("cart", 2)
+	trie.Insert("cat", 3)
+	trie.Insert("dog", 4)
+	trie.Insert("car", 5)
+
+	if trie.Len() != 4 {
+		t.Errorf("Trie failed: expected 4 keys, got %d", trie.Len())
+	}
+	if v, found := trie.Get("car"); !found || v != 5 {
+		t.Errorf("Trie failed: expected car=5, got %d, found %v", v, found)
+	}
+	if _, found := trie.Get("ca"); found {
+		t.Error("Trie failed: expected ca to be missing")
+	}
+
+	keys := []string{}
+	for key := range trie.WalkPrefix("ca") {
+		keys = append(keys, key)
+	}
+	expected := []string{"car", "cart", "cat"}
+	if !slices.Equal(keys, expected) {
+		t.Errorf("Trie failed: expected %v, got %v", expected, keys)
+	}
+
+	if !trie.Delete("car") || trie.Delete("car") {
+		t.Error("Trie failed: expected Delete to report presence once")
+	}
+	if v, found := trie.Get("cart"); !found || v != 2 {
+		t.Errorf("Trie failed: expected cart=2 after delete, got %d, found %v", v, found)
+	}
+	if !trie.Delete("cart") || trie.Len() != 2 {
+		t.Errorf("Trie failed: expected 2 keys after deletes, got %d", trie.Len())
+	}
+	keys = keys[:0]
+	for key := range trie.WalkPrefix("") {
+		keys = append(keys, key)
+	}
+	if !slices.Equal(keys, []string{"cat", "dog"}) {
+		t.Errorf("Trie failed: expected [cat dog], got %v", keys)
+	}
+}
+
+func TestTrieLongestPrefix(t *testing.T) {
+	var routes goassist.Trie[string]
+	routes.Insert("/api", "api")
+	routes.Insert("/api/users", "users")
+
+	key, handler, found := routes.LongestPrefix("/api/users/42")
+	if !found || key != "/api/users" || handler != "users" {
+		t.Errorf("LongestPrefix failed: expected /api/users, got %q %q %v", key, handler, found)
+	}
+	key, handler, found = routes.LongestPrefix("/api/orders")
+	if !found || key != "/api" || handler != "api" {
+		t.Errorf("LongestPrefix failed: expected /api, got %q %q %v", key, handler, found)
+	}
+	if _, _, found = routes.LongestPrefix("/static"); found {
+		t.Error("LongestPrefix failed: expected no match")
+	}
+}
+
+func TestBuildTrie(t *testing.T) {
+	type City struct {
+		Name       string
+		Population int
+	}
+	cities := []City{{"Berlin", 3645000}, {"Bern", 134000}, {"Boston", 675000}}
+	trie := goassist.BuildTrie(cities, func(c City) string {
+		return strings.ToLower(c.Name)
+	})
+	if berlin, found := trie.Get("berlin"); !found || berlin.Population != 3645000 {
+		t.Errorf("BuildTrie failed: expected Berlin, got %v, found %v", berlin, found)
+	}
+	names := []string{}
+	for _, c := range trie.WalkPrefix("ber") {
+		names = append(names, c.Name)
+	}
+	if !slices.Equal(names, []string{"Berlin", "Bern"}) {
+		t.Errorf("BuildTrie failed: expected [Berlin Bern], got %v", names)
+	}
+}
+
+func TestRuneTrie(t *testing.T) {
+	words := []string{"привет", "пример", "пока"}
+	trie := goassist.BuildRuneTrie(words, func(s string) string { return s })
+
+	keys := []string{}
+	for key := range trie.WalkPrefix("при") {
+		keys = append(keys, key)
+	}
+	if !slices.Equal(keys, []string{"привет", "пример"}) {
+		t.Errorf("RuneTrie failed: expected [привет пример], got %v", keys)
+	}
+	key, _, found := trie.LongestPrefix("поками")
+	if !found || key != "пока" {
+		t.Errorf("RuneTrie failed: expected пока, got %q, found %v", key, found)
+	}
+	if !trie.Delete("пока") || trie.Len() != 2 {
+		t.Errorf("RuneTrie failed: expected 2 keys after delete, got %d", trie.Len())
+	}
+}
+
+func TestRuneTrieInvalidUTF8(t *testing.T) {
+	trie := goassist.NewRuneTrie[int]()
+	trie.Insert("a\xff", 1)
+	trie.Insert("a\xfe", 2)
+	trie.Insert("a\uFFFD", 3)
+	if trie.Len() != 3 {
+		t.Fatalf("RuneTrie failed: expected invalid bytes not to collide, got %d keys", trie.Len())
+	}
+	if v, ok := trie.Get("a\xfe"); !ok || v != 2 {
+		t.Errorf("RuneTrie.Get failed: expected 2, got %d, %v", v, ok)
+	}
+
+	key, v, found := trie.LongestPrefix("a\xffzzz")
+	if !found || key != "a\xff" || v != 1 {
+		t.Errorf("RuneTrie.LongestPrefix failed: expected \"a\\xff\", got %q, %d, %v", key, v, found)
+	}
+	if key, _, found := trie.LongestPrefix("a\xfdzzz"); found {
+		t.Errorf("RuneTrie.LongestPrefix failed: expected no match, got %q", key)
+	}
+
+	var keys []string
+	for key := range trie.WalkPrefix("a") {
+		keys = append(keys, key)
+	}
+	if !slices.Equal(keys, []string{"a\uFFFD", "a\xfe", "a\xff"}) {
+		t.Errorf("RuneTrie.WalkPrefix failed: got %q", keys)
+	}
+}
+
+func TestTrieEncoding(t *testing.T) {
+	trie := goassist.NewTrie[int]()
+	trie.Insert("cat", 3)
+	trie.Insert("car", 1)
+	trie.Insert("", 0)
+	data, err := json.Marshal(trie)
+	if err != nil || string(data) != `{"":0,"car":1,"cat":3}` {
+		t.Fatalf("Trie.MarshalJSON failed: got %s, %v", data, err)
+	}
+	decoded := goassist.NewTrie[int]()
+	decoded.Insert("stale", 9)
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Trie.UnmarshalJSON failed: %v", err)
+	}
+	if v, ok := decoded.Get("car"); decoded.Len() != 3 || !ok || v != 1 {
+		t.Errorf("Trie.UnmarshalJSON failed: got %d keys, car=%d", decoded.Len(), v)
+	}
+
+	var first, second bytes.Buffer
+	if err := gob.NewEncoder(&first).Encode(trie); err != nil {
+		t.Fatalf("Trie gob encode failed: %v", err)
+	}
+	gob.NewEncoder(&second).Encode(decoded)
+	if !bytes.Equal(first.Bytes(), second.Bytes()) {
+		t.Error("Trie gob encode failed: expected equal tries to have equal encodings")
+	}
+	var viaGob goassist.Trie[int]
+	if err := gob.NewDecoder(&first).Decode(&viaGob); err != nil || viaGob.Len() != 3 {
+		t.Errorf("Trie gob round trip failed: got %d keys, %v", viaGob.Len(), err)
+	}
+
+	runes := goassist.NewRuneTrie[string]()
+	runes.Insert("пример", "example")
+	runes.Insert("привет", "hello")
+	data, _ = json.Marshal(runes)
+	var runesDecoded goassist.RuneTrie[string]
+	if err := json.Unmarshal(data, &runesDecoded); err != nil {
+		t.Fatalf("RuneTrie.UnmarshalJSON failed: %v", err)
+	}
+	if v, _ := runesDecoded.Get("привет"); v != "hello" {
+		t.Errorf("RuneTrie JSON round trip failed: got %q", v)
+	}
+	var buf bytes.Buffer
+	gob.NewEncoder(&buf).Encode(runes)
+	var runesGob goassist.RuneTrie[string]
+	if err := gob.NewDecoder(&buf).Decode(&runesGob); err != nil || runesGob.Len() != 2 {
+		t.Errorf("RuneTrie gob round trip failed: got %d keys, %v", runesGob.Len(), err)
+	}
+
+	// JSON cannot hold invalid UTF-8, so such keys are rejected rather than merged; gob keeps them.
+	invalid := goassist.NewRuneTrie[int]()
+	invalid.Insert("a\xff", 1)
+	invalid.Insert("a\xfe", 2)
+	if _, err := json.Marshal(invalid); !errors.Is(err, goassist.ErrTrieKeyNotUTF8) {
+		t.Errorf("RuneTrie.MarshalJSON failed: expected ErrTrieKeyNotUTF8, got %v", err)
+	}
+	bytesTrie := goassist.NewTrie[int]()
+	bytesTrie.Insert("a\xff", 1)
+	if _, err := json.Marshal(bytesTrie); !errors.Is(err, goassist.ErrTrieKeyNotUTF8) {
+		t.Errorf("Trie.MarshalJSON failed: expected ErrTrieKeyNotUTF8, got %v", err)
+	}
+	buf.Reset()
+	gob.NewEncoder(&buf).Encode(invalid)
+	var invalidGob goassist.RuneTrie[int]
+	if err := gob.NewDecoder(&buf).Decode(&invalidGob); err != nil || invalidGob.Len() != 2 {
+		t.Fatalf("RuneTrie gob round trip failed: got %d keys, %v", invalidGob.Len(), err)
+	}
+	if v, ok := invalidGob.Get("a\xfe"); !ok || v != 2 {
+		t.Errorf("RuneTrie gob round trip failed: a\\xfe=%d, %v", v, ok)
+	}
+}
+
+func benchmarkWords() []string {
+	words := make([]string, 0, 50000)
+	for i := range 50000 {
+		words = append(words, fmt.Sprintf("%c%c%05d", 'a'+i%26, 'a'+i/26%26, i))
+	}
+	slices.Sort(words)
+	return words
+}
+
+func BenchmarkTrieWalkPrefix(b *testing.B) {
+	trie := goassist.BuildTrie(benchmarkWords(), func(s string) string { return s })
+	b.ResetTimer()
+	for range b.N {
+		n := 0
+		for range trie.WalkPrefix("km") {
+			n++
+		}
+	}
+}
+
+func BenchmarkBinarySearchPrefix(b *testing.B) {
+	words := benchmarkWords()
+	b.ResetTimer()
+	for range b.N {
+		n := 0
+		i, _ := goassist.BinarySearch(words, "km")
+		for ; i < len(words) && strings.HasPrefix(words[i], "km"); i++ {
+			n++
+		}
+	}
+}
+
+func BenchmarkTrieGet(b *testing.B) {
+	words := benchmarkWords()
+	trie := goassist.BuildTrie(words, func(s string) string { return s })
+	b.ResetTimer()
+	for i := range b.N {
+		trie.Get(words[i%len(words)])
+	}
+}
+
+func BenchmarkBinarySearchGet(b *testing.B) {
+	words := benchmarkWords()
+	b.ResetTimer()
+	for i := range b.N {
+		goassist.BinarySearch(words, words[i%len(words)])
+	}
+}
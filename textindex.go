@@ -0,0 +1,283 @@
+package goassist
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// DefaultStopWords are common English words that TextIndex leaves out of the index and of queries
+// unless TextIndexOptions.StopWords says otherwise.
+var DefaultStopWords = []string{
+	"a", "an", "and", "are", "as", "at", "be", "but", "by", "for", "if", "in", "into", "is", "it",
+	"no", "not", "of", "on", "or", "such", "that", "the", "their", "then", "there", "these",
+	"they", "this", "to", "was", "will", "with",
+}
+
+// TextIndexOptions configures NewTextIndex. The zero value uses DefaultStopWords and the usual
+// BM25 parameters.
+type TextIndexOptions struct {
+	// StopWords are words left out of the index and of queries. Nil means DefaultStopWords;
+	// an empty, non-nil slice keeps every word.
+	StopWords []string
+	// K1 controls how quickly repeated terms stop adding to the score. Zero means 1.2.
+	K1 float64
+	// B controls how much long documents are penalized, from 0 to 1. Zero means 0.75;
+	// set DisableLengthNorm to use 0.
+	B float64
+	// DisableLengthNorm turns off document length normalization, as B = 0 does in BM25,
+	// so that long documents are not penalized. B is ignored when it is set.
+	DisableLengthNorm bool
+}
+
+// TextHit is a document found by TextIndex.SearchHits, with its index in the indexed slice
+// and its BM25 score.
+type TextHit[T any] struct {
+	Value T
+	Index int
+	Score float64
+}
+
+// TextIndex is an inverted index for ranked full-text search over a slice of documents.
+// Text is split into words of letters and digits and lowercased; stop words are dropped.
+//
+// Queries are made of words, all of which must match by default:
+//   - "word*" matches every word starting with "word";
+//   - "-word" or "NOT word" excludes documents containing the word;
+//   - "OR" separates alternatives, each of them a list of words as above;
+//   - "AND" is accepted and ignored.
+//
+// Results are ranked with BM25, best first; documents with equal scores keep their original order.
+// An alternative without positive words matches nothing.
+//
+// Example:
+//
+//	type Ticket struct {
+//		ID    int
+//		Title string
+//		Body  string
+//	}
+//	index := NewTextIndex(tickets, func(t Ticket) string {
+//		return t.Title + " " + t.Body
+//	}, TextIndexOptions{})
+//	hits := index.Search("login timeout -mobile")
+//	// hits holds the tickets mentioning both "login" and "timeout" but not "mobile", best first
+type TextIndex[T any] struct {
+	docs      []T
+	lengths   []int
+	avgLength float64
+	terms     *Trie[[]textPosting]
+	stopWords map[string]struct{}
+	k1, b     float64
+}
+
+type textPosting struct {
+	doc, freq int
+}
+
+// NewTextIndex builds a TextIndex over the slice, indexing the text returned by textFn
+// for every element.
+func NewTextIndex[T any](docs []T, textFn func(T) string, opts TextIndexOptions) *TextIndex[T] {
+	stopWords := opts.StopWords
+	if stopWords == nil {
+		stopWords = DefaultStopWords
+	}
+	x := &TextIndex[T]{
+		docs:      Clone(docs),
+		lengths:   make([]int, len(docs)),
+		terms:     NewTrie[[]textPosting](),
+		stopWords: make(map[string]struct{}, len(stopWords)),
+		k1:        opts.K1,
+		b:         opts.B,
+	}
+	if x.k1 == 0 {
+		x.k1 = 1.2
+	}
+	if opts.DisableLengthNorm {
+		x.b = 0
+	} else if x.b == 0 {
+		x.b = 0.75
+	}
+	for _, w := range stopWords {
+		x.stopWords[strings.ToLower(w)] = struct{}{}
+	}
+
+	postings := make(map[string][]textPosting)
+	total := 0
+	for i, doc := range docs {
+		freqs := make(map[string]int)
+		for _, term := range x.tokenize(textFn(doc)) {
+			freqs[term]++
+			x.lengths[i]++
+		}
+		for term, freq := range freqs {
+			postings[term] = append(postings[term], textPosting{doc: i, freq: freq})
+		}
+		total += x.lengths[i]
+	}
+	for term, list := range postings {
+		SortFunc(list, func(a, b textPosting) int { return a.doc - b.doc })
+		x.terms.Insert(term, list)
+	}
+	if len(docs) > 0 {
+		x.avgLength = float64(total) / float64(len(docs))
+	}
+	return x
+}
+
+// Len returns the number of indexed documents.
+func (x *TextIndex[T]) Len() int {
+	return len(x.docs)
+}
+
+// Search returns the documents matching the query, best first.
+func (x *TextIndex[T]) Search(query string) []T {
+	return Map(x.SearchHits(query), func(h TextHit[T]) T { return h.Value })
+}
+
+// SearchHits is like Search but also returns the index and score of every document.
+func (x *TextIndex[T]) SearchHits(query string) []TextHit[T] {
+	scores := make(map[int]float64)
+	for _, alt := range x.parseQuery(query) {
+		for doc, score := range x.matchAll(alt) {
+			scores[doc] = max(scores[doc], score)
+		}
+	}
+
+	hits := make([]TextHit[T], 0, len(scores))
+	for doc, score := range scores {
+		hits = append(hits, TextHit[T]{Value: x.docs[doc], Index: doc, Score: score})
+	}
+	SortFunc(hits, func(a, b TextHit[T]) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		default:
+			return a.Index - b.Index
+		}
+	})
+	return hits
+}
+
+// textTerm is a query word, matched exactly or as a prefix.
+type textTerm struct {
+	word   string
+	prefix bool
+}
+
+// textAlternative is one OR branch of a query: all of include and none of exclude must match.
+type textAlternative struct {
+	include, exclude []textTerm
+}
+
+func (x *TextIndex[T]) parseQuery(query string) []textAlternative {
+	alts := []textAlternative{{}}
+	negate := false
+	for _, field := range strings.Fields(query) {
+		switch field {
+		case "OR":
+			alts = append(alts, textAlternative{})
+			negate = false
+			continue
+		case "AND":
+			continue
+		case "NOT":
+			negate = true
+			continue
+		}
+
+		exclude := negate
+		negate = false
+		if rest, ok := strings.CutPrefix(field, "-"); ok {
+			field, exclude = rest, true
+		}
+		prefix := strings.HasSuffix(field, "*")
+
+		words := textWords(field)
+		alt := &alts[len(alts)-1]
+		for i, w := range words {
+			// Only the last word of "e-mai*" is a prefix. Stop words are dropped from exact
+			// terms only: "in*" must still match "index".
+			term := textTerm{word: w, prefix: prefix && i == len(words)-1}
+			if _, stop := x.stopWords[w]; stop && !term.prefix {
+				continue
+			}
+			if exclude {
+				alt.exclude = append(alt.exclude, term)
+			} else {
+				alt.include = append(alt.include, term)
+			}
+		}
+	}
+	return alts
+}
+
+// matchAll returns the BM25 score of every document matching the alternative.
+func (x *TextIndex[T]) matchAll(alt textAlternative) map[int]float64 {
+	if len(alt.include) == 0 {
+		return nil
+	}
+
+	var scores map[int]float64
+	for _, term := range alt.include {
+		termScores := x.scoreTerm(term)
+		if scores == nil {
+			scores = termScores
+			continue
+		}
+		for doc := range scores {
+			if s, ok := termScores[doc]; ok {
+				scores[doc] += s
+			} else {
+				delete(scores, doc)
+			}
+		}
+	}
+	for _, term := range alt.exclude {
+		for doc := range x.scoreTerm(term) {
+			delete(scores, doc)
+		}
+	}
+	return scores
+}
+
+// scoreTerm returns the BM25 score of the term for every document containing it.
+// A prefix term scores as the sum of the words it expands to.
+func (x *TextIndex[T]) scoreTerm(term textTerm) map[int]float64 {
+	scores := make(map[int]float64)
+	add := func(postings []textPosting) {
+		n := float64(len(x.docs))
+		df := float64(len(postings))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for _, p := range postings {
+			tf := float64(p.freq)
+			norm := 1 - x.b + x.b*float64(x.lengths[p.doc])/x.avgLength
+			scores[p.doc] += idf * tf * (x.k1 + 1) / (tf + x.k1*norm)
+		}
+	}
+
+	if term.prefix {
+		for _, postings := range x.terms.WalkPrefix(term.word) {
+			add(postings)
+		}
+	} else if postings, ok := x.terms.Get(term.word); ok {
+		add(postings)
+	}
+	return scores
+}
+
+func (x *TextIndex[T]) tokenize(s string) []string {
+	return Filter(textWords(s), func(w string) bool {
+		_, stop := x.stopWords[w]
+		return !stop
+	})
+}
+
+// textWords splits s into lowercase words of letters and digits.
+func textWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,174 @@
+package goassist
+
+import "iter"
+
+// Node is an element of a tree built by BuildTree.
+type Node[T any] struct {
+	Value    T
+	Children []*Node[T]
+}
+
+// FlatNode is a tree node flattened by FlattenTree. Parent is the index of the parent
+// in the flattened slice, or -1 for roots.
+type FlatNode[T any] struct {
+	Value  T
+	Depth  int
+	Parent int
+}
+
+// BuildTree turns flat rows referencing their parent by ID into a forest.
+// Rows whose parent ID matches no row become roots. Children keep the order of the input slice.
+// Rows that are part of a parent cycle cannot be reached from any root and are left out.
+//
+// Example:
+//
+//	type Category struct {
+//		ID       int
+//		ParentID int
+//		Name     string
+//	}
+//	rows := []Category{
+//		{1, 0, "Electronics"},
+//		{2, 1, "Phones"},
+//		{3, 1, "Laptops"},
+//		{4, 0, "Books"},
+//	}
+//	roots := BuildTree(rows, func(c Category) int {
+//		return c.ID
+//	}, func(c Category) int {
+//		return c.ParentID
+//	})
+//	// roots[0] is Electronics with children Phones and Laptops, roots[1] is Books
+func BuildTree[T any, K comparable](arr []T, idFn func(T) K, parentFn func(T) K) []*Node[T] {
+	nodes := make([]*Node[T], len(arr))
+	byID := make(map[K]*Node[T], len(arr))
+	for i, v := range arr {
+		nodes[i] = &Node[T]{Value: v}
+		byID[idFn(v)] = nodes[i]
+	}
+
+	roots := make([]*Node[T], 0)
+	for i, v := range arr {
+		parent, ok := byID[parentFn(v)]
+		if !ok || parent == nodes[i] {
+			roots = append(roots, nodes[i])
+			continue
+		}
+		parent.Children = append(parent.Children, nodes[i])
+	}
+	return roots
+}
+
+// WalkDepthFirst returns an iterator over the depth and node of every tree node in preorder.
+// Roots have depth 0.
+//
+// Example:
+//
+//	for depth, n := range WalkDepthFirst(roots) {
+//		fmt.Println(strings.Repeat("  ", depth) + n.Value.Name)
+//	}
+//	// Electronics
+//	//   Phones
+//	//   Laptops
+//	// Books
+func WalkDepthFirst[T any](roots []*Node[T]) iter.Seq2[int, *Node[T]] {
+	return func(yield func(int, *Node[T]) bool) {
+		walkDepthFirst(roots, 0, yield)
+	}
+}
+
+func walkDepthFirst[T any](nodes []*Node[T], depth int, yield func(int, *Node[T]) bool) bool {
+	for _, n := range nodes {
+		if !yield(depth, n) || !walkDepthFirst(n.Children, depth+1, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// WalkBreadthFirst returns an iterator over the depth and node of every tree node, level by level.
+// Roots have depth 0.
+//
+// Example:
+//
+//	for depth, n := range WalkBreadthFirst(roots) {
+//		fmt.Println(depth, n.Value.Name)
+//	}
+//	// 0 Electronics
+//	// 0 Books
+//	// 1 Phones
+//	// 1 Laptops
+func WalkBreadthFirst[T any](roots []*Node[T]) iter.Seq2[int, *Node[T]] {
+	return func(yield func(int, *Node[T]) bool) {
+		level := roots
+		for depth := 0; len(level) > 0; depth++ {
+			next := make([]*Node[T], 0)
+			for _, n := range level {
+				if !yield(depth, n) {
+					return
+				}
+				next = append(next, n.Children...)
+			}
+			level = next
+		}
+	}
+}
+
+// MapTree applies a function to the value of every node and returns a new forest of the same shape.
+//
+// Example:
+//
+//	names := MapTree(roots, func(c Category) string {
+//		return c.Name
+//	})
+//	// names[0].Value is "Electronics", names[0].Children[0].Value is "Phones"
+func MapTree[T any, R any](roots []*Node[T], fn func(T) R) []*Node[R] {
+	return Map(roots, func(n *Node[T]) *Node[R] {
+		return &Node[R]{Value: fn(n.Value), Children: MapTree(n.Children, fn)}
+	})
+}
+
+// FilterTree returns a new forest containing the nodes that satisfy the predicate function
+// together with all of their ancestors, so that every match keeps its path from the root.
+//
+// Example:
+//
+//	found := FilterTree(roots, func(c Category) bool {
+//		return strings.Contains(c.Name, "Phone")
+//	})
+//	// found is Electronics with the single child Phones
+func FilterTree[T any](roots []*Node[T], fn func(T) bool) []*Node[T] {
+	result := make([]*Node[T], 0)
+	for _, n := range roots {
+		children := FilterTree(n.Children, fn)
+		if len(children) > 0 || fn(n.Value) {
+			result = append(result, &Node[T]{Value: n.Value, Children: children})
+		}
+	}
+	return result
+}
+
+// FlattenTree turns a forest back into a slice in preorder, recording the depth
+// and parent index of every node.
+//
+// Example:
+//
+//	flat := FlattenTree(roots)
+//	// flat[0] is {Electronics, Depth: 0, Parent: -1}
+//	// flat[1] is {Phones, Depth: 1, Parent: 0}
+//	// flat[2] is {Laptops, Depth: 1, Parent: 0}
+//	// flat[3] is {Books, Depth: 0, Parent: -1}
+func FlattenTree[T any](roots []*Node[T]) []FlatNode[T] {
+	result := make([]FlatNode[T], 0)
+	// parents[d] is the index of the most recent node at depth d.
+	parents := make([]int, 0)
+	for depth, n := range WalkDepthFirst(roots) {
+		parents = append(parents[:depth], len(result))
+		parent := -1
+		if depth > 0 {
+			parent = parents[depth-1]
+		}
+		result = append(result, FlatNode[T]{Value: n.Value, Depth: depth, Parent: parent})
+	}
+	return result
+}
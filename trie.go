@@ -0,0 +1,472 @@
+package goassist
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidTrieEncoding is returned by Trie.GobDecode and RuneTrie.GobDecode for malformed input.
+var ErrInvalidTrieEncoding = errors.New("goassist: invalid trie encoding")
+
+// ErrTrieKeyNotUTF8 is returned by Trie.MarshalJSON and RuneTrie.MarshalJSON for keys that are
+// not valid UTF-8, which JSON strings cannot represent.
+var ErrTrieKeyNotUTF8 = errors.New("goassist: trie key is not valid UTF-8")
+
+// Trie is a prefix tree mapping string keys to values. Keys are split into bytes,
+// so every operation runs in O(len(key)) regardless of the number of stored keys.
+// Use RuneTrie when keys should be split into runes instead.
+//
+// The zero value is an empty trie ready to use.
+//
+// Example:
+//
+//	t := NewTrie[int]()
+//	t.Insert("car", 1)
+//	t.Insert("cart", 2)
+//	t.Insert("cat", 3)
+//	for key, v := range t.WalkPrefix("car") {
+//		fmt.Println(key, v)
+//	}
+//	// car 1
+//	// cart 2
+type Trie[V any] struct {
+	t trie[byte, V]
+}
+
+// NewTrie creates an empty Trie.
+func NewTrie[V any]() *Trie[V] {
+	return &Trie[V]{}
+}
+
+// BuildTrie creates a Trie holding every element of the slice under the key returned by keyFn.
+// When several elements share a key, the last one wins.
+//
+// Example:
+//
+//	type City struct {
+//		Name       string
+//		Population int
+//	}
+//	cities := []City{{"Berlin", 3645000}, {"Bern", 134000}, {"Boston", 675000}}
+//	t := BuildTrie(cities, func(c City) string {
+//		return strings.ToLower(c.Name)
+//	})
+//	berlin, found := t.Get("berlin")
+//	// berlin.Population is 3645000, found is true
+func BuildTrie[T any](arr []T, keyFn func(T) string) *Trie[T] {
+	t := NewTrie[T]()
+	for _, v := range arr {
+		t.Insert(keyFn(v), v)
+	}
+	return t
+}
+
+// Len returns the number of keys stored in the trie.
+func (t *Trie[V]) Len() int {
+	return t.t.size
+}
+
+// Insert stores v under key, replacing any previous value.
+func (t *Trie[V]) Insert(key string, v V) {
+	t.t.insert([]byte(key), v)
+}
+
+// Get returns the value stored under key and a boolean indicating if it was found.
+func (t *Trie[V]) Get(key string) (V, bool) {
+	return t.t.get([]byte(key))
+}
+
+// Delete removes key from the trie and reports whether it was present.
+func (t *Trie[V]) Delete(key string) bool {
+	return t.t.delete([]byte(key))
+}
+
+// LongestPrefix returns the longest stored key that is a prefix of s, together with its value.
+//
+// Example:
+//
+//	routes := NewTrie[string]()
+//	routes.Insert("/api", "api")
+//	routes.Insert("/api/users", "users")
+//	key, handler, found := routes.LongestPrefix("/api/users/42")
+//	// key is "/api/users", handler is "users", found is true
+func (t *Trie[V]) LongestPrefix(s string) (string, V, bool) {
+	n, v, found := t.t.longestPrefix([]byte(s))
+	return s[:n], v, found
+}
+
+// WalkPrefix returns an iterator over all keys starting with prefix and their values,
+// in ascending byte order.
+func (t *Trie[V]) WalkPrefix(prefix string) iter.Seq2[string, V] {
+	return t.t.walkPrefix([]byte(prefix), func(key []byte) string {
+		return string(key)
+	})
+}
+
+// MarshalJSON encodes the trie as a JSON object mapping every key to its value.
+// Keys are written in ascending order, so equal tries always have equal encodings.
+// It returns an error wrapping ErrTrieKeyNotUTF8 if a key is not valid UTF-8, since
+// encoding/json would replace its invalid bytes; use GobEncode to keep such keys.
+//
+// Example:
+//
+//	t := NewTrie[int]()
+//	t.Insert("cat", 3)
+//	t.Insert("car", 1)
+//	data, err := json.Marshal(t)
+//	// data is {"car":1,"cat":3}
+func (t *Trie[V]) MarshalJSON() ([]byte, error) {
+	return marshalTrieJSON(t.WalkPrefix(""))
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON, replacing the contents of t.
+func (t *Trie[V]) UnmarshalJSON(data []byte) error {
+	t.t = trie[byte, V]{}
+	return unmarshalTrieJSON(data, t.Insert)
+}
+
+// GobEncode encodes the keys and values of the trie in ascending key order.
+func (t *Trie[V]) GobEncode() ([]byte, error) {
+	return encodeTrieGob(t.WalkPrefix(""))
+}
+
+// GobDecode decodes data produced by GobEncode, replacing the contents of t.
+func (t *Trie[V]) GobDecode(data []byte) error {
+	t.t = trie[byte, V]{}
+	return decodeTrieGob(data, t.Insert)
+}
+
+// RuneTrie is a prefix tree mapping string keys to values where keys are split into runes.
+// Compared to Trie it uses fewer, wider nodes for non-ASCII text, and every prefix it
+// reports ends on a rune boundary.
+//
+// Keys do not have to be valid UTF-8. Each byte that is not part of a valid encoding is
+// kept as a separate unit, distinct from every rune and from the other bytes, so different
+// keys never collide and keys are returned exactly as they were inserted. Such bytes sort
+// after all runes.
+//
+// The zero value is an empty trie ready to use.
+//
+// Example:
+//
+//	t := NewRuneTrie[int]()
+//	t.Insert("привет", 1)
+//	t.Insert("пример", 2)
+//	for key := range t.WalkPrefix("при") {
+//		fmt.Println(key)
+//	}
+//	// привет
+//	// пример
+type RuneTrie[V any] struct {
+	t trie[rune, V]
+}
+
+// NewRuneTrie creates an empty RuneTrie.
+func NewRuneTrie[V any]() *RuneTrie[V] {
+	return &RuneTrie[V]{}
+}
+
+// BuildRuneTrie creates a RuneTrie holding every element of the slice under the key returned by keyFn.
+// When several elements share a key, the last one wins.
+func BuildRuneTrie[T any](arr []T, keyFn func(T) string) *RuneTrie[T] {
+	t := NewRuneTrie[T]()
+	for _, v := range arr {
+		t.Insert(keyFn(v), v)
+	}
+	return t
+}
+
+// Len returns the number of keys stored in the trie.
+func (t *RuneTrie[V]) Len() int {
+	return t.t.size
+}
+
+// Insert stores v under key, replacing any previous value.
+func (t *RuneTrie[V]) Insert(key string, v V) {
+	t.t.insert(runeTrieKey(key), v)
+}
+
+// Get returns the value stored under key and a boolean indicating if it was found.
+func (t *RuneTrie[V]) Get(key string) (V, bool) {
+	return t.t.get(runeTrieKey(key))
+}
+
+// Delete removes key from the trie and reports whether it was present.
+func (t *RuneTrie[V]) Delete(key string) bool {
+	return t.t.delete(runeTrieKey(key))
+}
+
+// LongestPrefix returns the longest stored key that is a prefix of s, together with its value.
+func (t *RuneTrie[V]) LongestPrefix(s string) (string, V, bool) {
+	n, v, found := t.t.longestPrefix(runeTrieKey(s))
+	offset := 0
+	for range n {
+		_, size := utf8.DecodeRuneInString(s[offset:])
+		offset += size
+	}
+	return s[:offset], v, found
+}
+
+// WalkPrefix returns an iterator over all keys starting with prefix and their values,
+// in ascending rune order.
+func (t *RuneTrie[V]) WalkPrefix(prefix string) iter.Seq2[string, V] {
+	return t.t.walkPrefix(runeTrieKey(prefix), runeTrieString)
+}
+
+// MarshalJSON encodes the trie as a JSON object mapping every key to its value.
+// Keys are written in ascending order, so equal tries always have equal encodings.
+// As with Trie.MarshalJSON, keys that are not valid UTF-8 yield an error wrapping ErrTrieKeyNotUTF8.
+func (t *RuneTrie[V]) MarshalJSON() ([]byte, error) {
+	return marshalTrieJSON(t.WalkPrefix(""))
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON, replacing the contents of t.
+func (t *RuneTrie[V]) UnmarshalJSON(data []byte) error {
+	t.t = trie[rune, V]{}
+	return unmarshalTrieJSON(data, t.Insert)
+}
+
+// GobEncode encodes the keys and values of the trie in ascending key order.
+func (t *RuneTrie[V]) GobEncode() ([]byte, error) {
+	return encodeTrieGob(t.WalkPrefix(""))
+}
+
+// GobDecode decodes data produced by GobEncode, replacing the contents of t.
+func (t *RuneTrie[V]) GobDecode(data []byte) error {
+	t.t = trie[rune, V]{}
+	return decodeTrieGob(data, t.Insert)
+}
+
+// runeTrieInvalid is the label of the invalid UTF-8 byte 0 in a RuneTrie; byte b is stored as
+// runeTrieInvalid+b. The labels lie above utf8.MaxRune, so they cannot clash with real runes.
+const runeTrieInvalid = utf8.MaxRune + 1
+
+// runeTrieKey splits s into runes, turning each invalid UTF-8 byte into its own label.
+func runeTrieKey(s string) []rune {
+	key := make([]rune, 0, len(s))
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			r = runeTrieInvalid + rune(s[i])
+		}
+		key = append(key, r)
+		i += size
+	}
+	return key
+}
+
+// runeTrieString is the inverse of runeTrieKey.
+func runeTrieString(key []rune) string {
+	var sb strings.Builder
+	for _, r := range key {
+		if r >= runeTrieInvalid {
+			sb.WriteByte(byte(r - runeTrieInvalid))
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+func marshalTrieJSON[V any](all iter.Seq2[string, V]) ([]byte, error) {
+	entries := make(map[string]V)
+	for key, v := range all {
+		if !utf8.ValidString(key) {
+			return nil, fmt.Errorf("%w: %q", ErrTrieKeyNotUTF8, key)
+		}
+		entries[key] = v
+	}
+	return json.Marshal(entries)
+}
+
+func unmarshalTrieJSON[V any](data []byte, insert func(string, V)) error {
+	var entries map[string]V
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	for key, v := range entries {
+		insert(key, v)
+	}
+	return nil
+}
+
+// trieEntries is the gob representation of Trie and RuneTrie. Parallel slices keep the
+// encoding deterministic, which a map would not.
+type trieEntries[V any] struct {
+	Keys   []string
+	Values []V
+}
+
+func encodeTrieGob[V any](all iter.Seq2[string, V]) ([]byte, error) {
+	var entries trieEntries[V]
+	for key, v := range all {
+		entries.Keys = append(entries.Keys, key)
+		entries.Values = append(entries.Values, v)
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(entries); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeTrieGob[V any](data []byte, insert func(string, V)) error {
+	var entries trieEntries[V]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&entries); err != nil {
+		return err
+	}
+	if len(entries.Keys) != len(entries.Values) {
+		return ErrInvalidTrieEncoding
+	}
+	for i, key := range entries.Keys {
+		insert(key, entries.Values[i])
+	}
+	return nil
+}
+
+type trieUnit interface {
+	byte | rune
+}
+
+// trie is the shared implementation of Trie and RuneTrie. Children are kept sorted
+// by label so that lookups use binary search and walks are ordered.
+type trie[K trieUnit, V any] struct {
+	root trieNode[K, V]
+	size int
+}
+
+type trieNode[K trieUnit, V any] struct {
+	labels   []K
+	children []*trieNode[K, V]
+	value    V
+	hasValue bool
+}
+
+func (n *trieNode[K, V]) child(label K) *trieNode[K, V] {
+	if i, found := slices.BinarySearch(n.labels, label); found {
+		return n.children[i]
+	}
+	return nil
+}
+
+func (t *trie[K, V]) find(key []K) *trieNode[K, V] {
+	n := &t.root
+	for _, label := range key {
+		if n = n.child(label); n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+func (t *trie[K, V]) insert(key []K, v V) {
+	n := &t.root
+	for _, label := range key {
+		i, found := slices.BinarySearch(n.labels, label)
+		if !found {
+			n.labels = slices.Insert(n.labels, i, label)
+			n.children = slices.Insert(n.children, i, &trieNode[K, V]{})
+		}
+		n = n.children[i]
+	}
+	if !n.hasValue {
+		t.size++
+	}
+	n.value, n.hasValue = v, true
+}
+
+func (t *trie[K, V]) get(key []K) (V, bool) {
+	if n := t.find(key); n != nil && n.hasValue {
+		return n.value, true
+	}
+
+	var zero V
+
+	return zero, false
+}
+
+func (t *trie[K, V]) delete(key []K) bool {
+	path := make([]*trieNode[K, V], 0, len(key)+1)
+	n := &t.root
+	path = append(path, n)
+	for _, label := range key {
+		if n = n.child(label); n == nil {
+			return false
+		}
+		path = append(path, n)
+	}
+	if !n.hasValue {
+		return false
+	}
+
+	var zero V
+
+	n.value, n.hasValue = zero, false
+	t.size--
+
+	// Prune nodes that no longer lead to any value.
+	for i := len(key) - 1; i >= 0; i-- {
+		child := path[i+1]
+		if child.hasValue || len(child.labels) > 0 {
+			break
+		}
+		parent := path[i]
+		j, _ := slices.BinarySearch(parent.labels, key[i])
+		parent.labels = slices.Delete(parent.labels, j, j+1)
+		parent.children = slices.Delete(parent.children, j, j+1)
+	}
+
+	return true
+}
+
+func (t *trie[K, V]) longestPrefix(s []K) (int, V, bool) {
+	var result V
+	length, found := 0, false
+
+	n := &t.root
+	if n.hasValue {
+		result, found = n.value, true
+	}
+	for i, label := range s {
+		if n = n.child(label); n == nil {
+			break
+		}
+		if n.hasValue {
+			result, length, found = n.value, i+1, true
+		}
+	}
+
+	return length, result, found
+}
+
+func (t *trie[K, V]) walkPrefix(prefix []K, toString func([]K) string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		n := t.find(prefix)
+		if n == nil {
+			return
+		}
+		key := slices.Clone(prefix)
+		n.walk(&key, toString, yield)
+	}
+}
+
+func (n *trieNode[K, V]) walk(key *[]K, toString func([]K) string, yield func(string, V) bool) bool {
+	if n.hasValue && !yield(toString(*key), n.value) {
+		return false
+	}
+	for i, child := range n.children {
+		*key = append(*key, n.labels[i])
+		if !child.walk(key, toString, yield) {
+			return false
+		}
+		*key = (*key)[:len(*key)-1]
+	}
+	return true
+}